@@ -0,0 +1,90 @@
+package goqty
+
+// Returns true if the quantity is measured on an absolute scale with an offset zero point,
+// e.g. tempC or psig, rather than as a difference, e.g. degC or psi
+func (q Qty) IsAffine() bool {
+	return q.expr != nil && q.expr.affine
+}
+
+func isAffine(num, den []term) bool {
+	return len(num) == 1 && len(den) == 0 && num[0].prefix == noUnit && num[0].power == 1 && unitInfos[num[0].unit].affine
+}
+
+// returns the plural noun used in error messages for an affine unit, e.g. temperatures
+func affineNoun(unit string) string {
+	return units[unit].kind + "s"
+}
+
+// returns a quantity of one of the difference unit paired with an affine unit, e.g. 1 degC for tempC
+func differenceUnit(unit string) (*Qty, error) {
+	return newQty(1, []string{affineUnits[unit].difference}, unityArray)
+}
+
+// returns the value of an affine quantity in the absolute base unit of its scale, e.g. 0 tempC => 273.15
+func (q Qty) affineBaseScalar() float64 {
+	u := units[q.singleUnit()]
+	a := affineUnits[q.singleUnit()]
+	return (q.scalar + a.offset) * u.scalar
+}
+
+// converts an affine quantity to the absolute base unit of its scale, e.g. 0 tempC => 273.15 tempK
+func (q Qty) affineToBase() (*Qty, error) {
+	return newQty(q.baseScalar, units[q.singleUnit()].numerator, unityArray)
+}
+
+// converts any compatible quantity to an affine unit.
+// linear quantities are interpreted as being relative to the absolute zero of the scale,
+// e.g. 100 degC => -173.15 tempC
+func toAffine(src, dst *Qty) (*Qty, error) {
+	u := units[dst.singleUnit()]
+	a := affineUnits[dst.singleUnit()]
+	return dst.withScalar(src.baseScalar/u.scalar - a.offset)
+}
+
+// converts any compatible quantity to a linear unit.
+// affine quantities are interpreted as being relative to the zero point of their own scale,
+// e.g. 100 tempC => 100 degC
+func toDifference(src, dst *Qty) (*Qty, error) {
+	relative := src.baseScalar
+	if src.IsAffine() {
+		relative = src.scalar * units[src.singleUnit()].scalar
+	}
+	if unit, err := toBaseUnits(dst.numTerms(), dst.denTerms()); err != nil {
+		return nil, err
+	} else {
+		return dst.withScalar(relative / unit.scalar)
+	}
+}
+
+// subtracts two affine quantities, the result is in the difference unit of the left hand side
+func subtractAffine(lhs, rhs *Qty) (*Qty, error) {
+	if r, err := rhs.To(lhs); err != nil {
+		return nil, err
+	} else if d, err := differenceUnit(lhs.singleUnit()); err != nil {
+		return nil, err
+	} else {
+		return d.withScalar(lhs.scalar - r.scalar)
+	}
+}
+
+// adds a difference to an affine quantity, the result is in the unit of the affine quantity
+func addAffineDifference(abs, diff *Qty) (*Qty, error) {
+	if d, err := differenceUnit(abs.singleUnit()); err != nil {
+		return nil, err
+	} else if d, err = diff.To(d); err != nil {
+		return nil, err
+	} else {
+		return abs.withScalar(abs.scalar + d.scalar)
+	}
+}
+
+// subtracts a difference from an affine quantity, the result is in the unit of the affine quantity
+func subtractAffineDifference(abs, diff *Qty) (*Qty, error) {
+	if d, err := differenceUnit(abs.singleUnit()); err != nil {
+		return nil, err
+	} else if d, err = diff.To(d); err != nil {
+		return nil, err
+	} else {
+		return abs.withScalar(abs.scalar - d.scalar)
+	}
+}
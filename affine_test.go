@@ -0,0 +1,156 @@
+package goqty
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAffineTo(t *testing.T) {
+	tests := map[string]struct {
+		expr   string
+		units  string
+		scalar float64
+	}{
+		"0 psig -> psia":        {"0 psig", "psia", 14.695943},
+		"0 psig -> Pa-abs":      {"0 psig", "Pa-abs", 101325},
+		"14.7 psia -> psig":     {"14.695943 psia", "psig", 0},
+		"1 barg -> bara":        {"1 barg", "bara", 2.01325},
+		"1 barg -> psig":        {"1 barg", "psig", 14.503768},
+		"10 psig -> psi":        {"10 psig", "psi", 10},
+		"10 psig -> Pa":         {"10 psig", "Pa", 68947.6},
+		"101325 Pa -> psia":     {"101325 Pa", "psia", 14.695943},
+		"100 tempC -> tempF":    {"100 tempC", "tempF", 212},
+		"100 tempC -> degC":     {"100 tempC", "degC", 100},
+		"100 degC -> tempC":     {"100 degC", "tempC", -173.15},
+		"32 tempF -> tempC":     {"32 tempF", "tempC", 0},
+		"491.67 tempR -> tempC": {"491.67 tempR", "tempC", 0},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q, err := Parse(test.expr)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.expr, err)
+				return
+			}
+			if actual, err := q.To(test.units); err != nil {
+				t.Errorf("failed to convert %v to %v, got %v", test.expr, test.units, err)
+			} else if math.Abs(actual.scalar-test.scalar) > 1e-6 {
+				t.Errorf("expected scalar %v, got %v", test.scalar, actual.scalar)
+			}
+		})
+	}
+}
+
+func TestAffineArithmetic(t *testing.T) {
+	tests := map[string]struct {
+		a        string
+		op       string
+		b        string
+		expected string
+	}{
+		"10 psig + 5 psi":   {"10 psig", "+", "5 psi", "15 psig"},
+		"5 psi + 10 psig":   {"5 psi", "+", "10 psig", "15 psig"},
+		"1 barg + 100 kPa":  {"1 barg", "+", "100 kPa", "2 barg"},
+		"10 psig - 5 psi":   {"10 psig", "-", "5 psi", "5 psig"},
+		"10 psig - 5 psig":  {"10 psig", "-", "5 psig", "5 psi"},
+		"2 barg - 1 bara":   {"2 barg", "-", "1 bara", "2.01325 bar"},
+		"10 psig + 5 psig":  {"10 psig", "+", "5 psig", "cannot add two pressures"},
+		"5 psi - 10 psig":   {"5 psi", "-", "10 psig", "cannot subtract an absolute pressure from a difference"},
+		"5 degC - 10 tempC": {"5 degC", "-", "10 tempC", "cannot subtract an absolute temperature from a difference"},
+		"10 psig * 2":       {"10 psig", "*", "2", "20 psig"},
+		"10 psig * 2 m":     {"10 psig", "*", "2 m", "cannot multiply by pressures"},
+		"10 psig / 2 m":     {"10 psig", "/", "2 m", "cannot divide with pressures"},
+		"2 m / 10 psig":     {"2 m", "/", "10 psig", "cannot divide with pressures"},
+		"10 psig + 1 m":     {"10 psig", "+", "1 m", "incompatible units: psig and m"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, err := Parse(test.a)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.a, err)
+				return
+			}
+			var actual *Qty
+			switch test.op {
+			case "+":
+				actual, err = a.Add(test.b)
+			case "-":
+				actual, err = a.Sub(test.b)
+			case "*":
+				actual, err = a.Mul(test.b)
+			case "/":
+				actual, err = a.Div(test.b)
+			}
+			if err != nil {
+				if err.Error() != test.expected {
+					t.Errorf("expected %v, got %v", test.expected, err)
+				}
+			} else if str := actual.String(); str != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, str)
+			}
+		})
+	}
+}
+
+func TestAffineFailure(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		expected string
+	}{
+		"-20 psig":  {"-20 psig", "pressures must not be less than absolute zero"},
+		"-1 psia":   {"-1 psia", "pressures must not be less than absolute zero"},
+		"1 psig/s":  {"1 psig/s", "cannot divide with pressures"},
+		"1 s/barg":  {"1 s/barg", "cannot divide with pressures"},
+		"1 psig*m":  {"1 psig*m", "cannot divide with pressures"},
+		"1 tempC/s": {"1 tempC/s", "cannot divide with temperatures"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if q, err := Parse(test.expr); err != nil {
+				if err.Error() != test.expected {
+					t.Errorf("expected %v, got %v", test.expected, err)
+				}
+			} else {
+				t.Errorf("expected error %v, got %v", test.expected, q)
+			}
+		})
+	}
+}
+
+func TestAffinePredicates(t *testing.T) {
+	tests := map[string]struct {
+		expr        string
+		affine      bool
+		temperature bool
+		degrees     bool
+		kind        string
+	}{
+		"tempC":  {"1 tempC", true, true, true, "temperature"},
+		"degC":   {"1 degC", false, false, true, "temperature"},
+		"psig":   {"1 psig", true, false, false, "pressure"},
+		"psi":    {"1 psi", false, false, false, "pressure"},
+		"Pa-abs": {"1 Pa-abs", true, false, false, "pressure"},
+		"m":      {"1 m", false, false, false, "length"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q, err := Parse(test.expr)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.expr, err)
+				return
+			}
+			if q.IsAffine() != test.affine {
+				t.Errorf("expected IsAffine %v, got %v", test.affine, q.IsAffine())
+			}
+			if q.IsTemperature() != test.temperature {
+				t.Errorf("expected IsTemperature %v, got %v", test.temperature, q.IsTemperature())
+			}
+			if q.IsDegrees() != test.degrees {
+				t.Errorf("expected IsDegrees %v, got %v", test.degrees, q.IsDegrees())
+			}
+			if q.Kind() != test.kind {
+				t.Errorf("expected kind %v, got %v", test.kind, q.Kind())
+			}
+		})
+	}
+}
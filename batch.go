@@ -0,0 +1,101 @@
+package goqty
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sync"
+)
+
+// ParallelBatchThreshold is the smallest batch that ConvertBatch splits across GOMAXPROCS goroutines.
+// Set it to math.MaxInt to always convert on the calling goroutine.
+var ParallelBatchThreshold = 1 << 16
+
+/* Converts values that each have their own units to the target units, e.g.
+ * ConvertBatch([]float64{12.3, 0.8, 101}, []string{"psi", "bar", "kPa"}, "kPa")
+ *
+ * The values are grouped by their units, and one converter is compiled for every distinct unit.
+ * A row that can't be converted, e.g. because its units are unknown or incompatible, doesn't stop the batch:
+ * its result is NaN and its error is at the same index in the row errors, which are nil if every row was converted.
+ * If the batch can't be converted at all, because the target units can't be parsed or the slices have different lengths,
+ * there are no results and the error is returned on its own.
+ */
+func ConvertBatch(values []float64, units []string, target string) ([]float64, []error, error) {
+	if len(units) != len(values) {
+		return nil, nil, fmt.Errorf("expected %v units, got %v", len(values), len(units))
+	}
+	to, err := ParseUnit(target)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the converter for every distinct unit, and the index of the converter for every row
+	var converters []*Converter
+	var groupErrs []error
+	groups := make([]int, len(values))
+	indexes := make(map[string]int)
+	for i, u := range units {
+		g, ok := indexes[u]
+		if !ok {
+			g = len(converters)
+			indexes[u] = g
+			c, err := batchConverter(u, to)
+			converters = append(converters, c)
+			groupErrs = append(groupErrs, err)
+		}
+		groups[i] = g
+	}
+
+	result := make([]float64, len(values))
+	errs := make([]error, len(values))
+	convertRows := func(start, end int) bool {
+		ok := true
+		for i := start; i < end; i++ {
+			g := groups[i]
+			if groupErrs[g] != nil {
+				errs[i], ok = groupErrs[g], false
+			} else if v, err := converters[g].convert(values[i]); err != nil {
+				errs[i], ok = err, false
+			} else {
+				result[i] = v
+				continue
+			}
+			result[i] = math.NaN()
+		}
+		return ok
+	}
+
+	ok := true
+	if workers := runtime.GOMAXPROCS(0); len(values) < ParallelBatchThreshold || workers < 2 {
+		ok = convertRows(0, len(values))
+	} else {
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+		size := (len(values) + workers - 1) / workers
+		for start := 0; start < len(values); start += size {
+			wg.Add(1)
+			go func(start, end int) {
+				defer wg.Done()
+				if !convertRows(start, end) {
+					mu.Lock()
+					ok = false
+					mu.Unlock()
+				}
+			}(start, min(start+size, len(values)))
+		}
+		wg.Wait()
+	}
+
+	if ok {
+		return result, nil, nil
+	}
+	return result, errs, nil
+}
+
+func batchConverter(units string, to Unit) (*Converter, error) {
+	if from, err := ParseUnit(units); err != nil {
+		return nil, fmt.Errorf("%v: %w", units, err)
+	} else {
+		return NewConverter(from, to)
+	}
+}
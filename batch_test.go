@@ -0,0 +1,106 @@
+package goqty
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConvertBatch(t *testing.T) {
+	values := []float64{12.3, 0.8, 101, 5, 3, 20}
+	units := []string{"psi", "bar", "kPa", "qq", "m", "tempC"}
+	expected := []float64{84.8055, 80, 101, math.NaN(), math.NaN(), math.NaN()}
+
+	actual, errs, err := ConvertBatch(values, units, "kPa")
+	if err != nil {
+		t.Fatalf("failed to convert, got %v", err)
+	} else if len(errs) != len(values) {
+		t.Fatalf("expected %v errors, got %v", len(values), errs)
+	}
+	for i := range values {
+		if math.IsNaN(expected[i]) {
+			if errs[i] == nil || !math.IsNaN(actual[i]) {
+				t.Errorf("expected row %v to fail, got %v", i, actual[i])
+			}
+		} else if errs[i] != nil {
+			t.Errorf("failed to convert row %v, got %v", i, errs[i])
+		} else if math.Abs(actual[i]-expected[i]) > 1e-4 {
+			t.Errorf("expected row %v to be %v, got %v", i, expected[i], actual[i])
+		}
+	}
+	if errs[3].Error() != "qq: unit not recognized" {
+		t.Errorf("expected qq: unit not recognized, got %v", errs[3])
+	}
+	if errs[4].Error() != "incompatible units: m and kPa" {
+		t.Errorf("expected incompatible units: m and kPa, got %v", errs[4])
+	}
+}
+
+func TestConvertBatchFailure(t *testing.T) {
+	tests := map[string]struct {
+		values   []float64
+		units    []string
+		target   string
+		expected string
+	}{
+		"lengths": {[]float64{1, 2}, []string{"m"}, "ft", "expected 2 units, got 1"},
+		"target":  {[]float64{1}, []string{"m"}, "qq", "unit not recognized"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual, errs, err := ConvertBatch(test.values, test.units, test.target); err == nil {
+				t.Errorf("expected error %v, got %v %v", test.expected, actual, errs)
+			} else if err.Error() != test.expected {
+				t.Errorf("expected error %v, got %v", test.expected, err)
+			} else if actual != nil || errs != nil {
+				t.Errorf("expected no results, got %v %v", actual, errs)
+			}
+		})
+	}
+
+	if actual, errs, err := ConvertBatch([]float64{1, 2}, []string{"m", "cm"}, "mm"); err != nil || errs != nil {
+		t.Errorf("expected no errors, got %v %v", err, errs)
+	} else if actual[0] != 1000 || actual[1] != 20 {
+		t.Errorf("expected [1000 20], got %v", actual)
+	}
+}
+
+func TestConvertBatchParallel(t *testing.T) {
+	defer func(threshold int) { ParallelBatchThreshold = threshold }(ParallelBatchThreshold)
+	ParallelBatchThreshold = 10
+
+	units := []string{"psi", "bar", "kPa", "m"}
+	values := make([]float64, 1000)
+	rows := make([]string, len(values))
+	for i := range values {
+		values[i] = float64(i)
+		rows[i] = units[i%len(units)]
+	}
+	actual, errs, err := ConvertBatch(values, rows, "kPa")
+	if err != nil {
+		t.Fatalf("failed to convert, got %v", err)
+	}
+	for i := range values {
+		if rows[i] == "m" {
+			if errs[i] == nil {
+				t.Errorf("expected row %v to fail", i)
+			}
+		} else if errs[i] != nil {
+			t.Errorf("failed to convert row %v, got %v", i, errs[i])
+		} else if rows[i] == "kPa" && actual[i] != values[i] {
+			t.Errorf("expected row %v to be %v, got %v", i, values[i], actual[i])
+		}
+	}
+}
+
+func BenchmarkConvertBatch(b *testing.B) {
+	units := []string{"psi", "bar", "kPa", "MPa", "atm", "mmHg"}
+	rows := make([]string, len(benchmarkValues))
+	for i := range rows {
+		rows[i] = units[i%len(units)]
+	}
+	for i := 0; i < b.N; i++ {
+		if _, errs, err := ConvertBatch(benchmarkValues, rows, "kPa"); err != nil || errs != nil {
+			b.Fatal(err, errs)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package goqty
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// CacheStatistics counts the lookups of a cache.
+type CacheStatistics struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+	Capacity  int
+}
+
+// lruCache is a size-bounded cache that evicts the least recently used entry, safe for concurrent use.
+// The caches are keyed by user-supplied strings, so they must not grow without limit.
+type lruCache[K comparable, V any] struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[K]*list.Element
+	order    *list.List // most recently used first
+	stats    CacheStatistics
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRUCache[K comparable, V any](capacity int) *lruCache[K, V] {
+	return &lruCache[K, V]{capacity: capacity, entries: make(map[K]*list.Element), order: list.New()}
+}
+
+func (c *lruCache[K, V]) Load(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.stats.Hits++
+		c.order.MoveToFront(e)
+		return e.Value.(*lruEntry[K, V]).value, true
+	}
+	c.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// stores a value unless the key is already cached, and returns the cached value
+func (c *lruCache[K, V]) LoadOrStore(key K, value V) V {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		return e.Value.(*lruEntry[K, V]).value
+	}
+	if c.capacity <= 0 {
+		return value
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key, value})
+	c.evict()
+	return value
+}
+
+func (c *lruCache[K, V]) evict() {
+	for len(c.entries) > c.capacity {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*lruEntry[K, V]).key)
+		c.stats.Evictions++
+	}
+}
+
+func (c *lruCache[K, V]) setCapacity(capacity int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity = max(capacity, 0)
+	c.evict()
+}
+
+func (c *lruCache[K, V]) statistics() CacheStatistics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Size = len(c.entries)
+	stats.Capacity = c.capacity
+	return stats
+}
+
+func (c *lruCache[K, V]) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+	c.order.Init()
+	c.stats = CacheStatistics{}
+}
+
+// the operations of an lruCache that don't depend on its types
+type cache interface {
+	setCapacity(capacity int)
+	statistics() CacheStatistics
+	reset()
+}
+
+// the caches by name, for CacheStats, SetCacheCapacity and ResetCaches
+var caches = map[string]cache{
+	"units":      parsedUnitsCache,
+	"strings":    stringifiedUnitsCache,
+	"converters": converters,
+}
+
+// Returns the statistics of the internal caches by name:
+// "units" for the units parsed by Parse, "strings" for formatted units and "converters" for NewConverter and To.
+func CacheStats() map[string]CacheStatistics {
+	result := make(map[string]CacheStatistics, len(caches))
+	for name, c := range caches {
+		result[name] = c.statistics()
+	}
+	return result
+}
+
+// Sets the number of entries kept by one of the caches named by CacheStats.
+// The least recently used entries are evicted, and a capacity of 0 disables the cache.
+func SetCacheCapacity(name string, capacity int) error {
+	if c, ok := caches[name]; !ok {
+		return fmt.Errorf("unknown cache %v", name)
+	} else {
+		c.setCapacity(capacity)
+		return nil
+	}
+}
+
+// Empties the caches and resets their statistics.
+// Interned units are not part of the caches; they are collected once no quantity or unit uses them.
+func ResetCaches() {
+	for _, c := range caches {
+		c.reset()
+	}
+}
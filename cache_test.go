@@ -0,0 +1,134 @@
+package goqty
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := newLRUCache[string, int](2)
+	c.LoadOrStore("a", 1)
+	c.LoadOrStore("b", 2)
+	c.Load("a") // b is now the least recently used
+	c.LoadOrStore("c", 3)
+
+	if _, ok := c.Load("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	if v, ok := c.Load("a"); !ok || v != 1 {
+		t.Errorf("expected a to be 1, got %v", v)
+	}
+	if v := c.LoadOrStore("c", 4); v != 3 {
+		t.Errorf("expected the cached 3, got %v", v)
+	}
+	expected := CacheStatistics{Hits: 2, Misses: 1, Evictions: 1, Size: 2, Capacity: 2}
+	if stats := c.statistics(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+
+	c.setCapacity(0)
+	c.LoadOrStore("d", 5)
+	if stats := c.statistics(); stats.Size != 0 || stats.Evictions != 3 {
+		t.Errorf("expected an empty cache after 3 evictions, got %+v", stats)
+	}
+}
+
+func TestCacheCapacity(t *testing.T) {
+	defer SetCacheCapacity("units", CacheStats()["units"].Capacity)
+	if err := SetCacheCapacity("units", 2); err != nil {
+		t.Fatalf("failed to set capacity, got %v", err)
+	}
+	for _, expr := range []string{"1 m", "1 kg", "1 s", "1 km", "1 m"} {
+		if _, err := Parse(expr); err != nil {
+			t.Errorf("failed to parse %v, got %v", expr, err)
+		}
+	}
+	if stats := CacheStats()["units"]; stats.Size != 2 || stats.Evictions == 0 {
+		t.Errorf("expected 2 units after evictions, got %+v", stats)
+	}
+
+	if err := SetCacheCapacity("parsed", 2); err == nil || err.Error() != "unknown cache parsed" {
+		t.Errorf("expected error unknown cache parsed, got %v", err)
+	}
+}
+
+func TestResetCaches(t *testing.T) {
+	a, _ := Parse("5 km/h")
+	if _, err := a.To("m/s"); err != nil {
+		t.Fatalf("failed to convert, got %v", err)
+	}
+	ResetCaches()
+	for name, stats := range CacheStats() {
+		if stats.Size != 0 || stats.Hits != 0 || stats.Misses != 0 {
+			t.Errorf("expected %v to be empty, got %+v", name, stats)
+		}
+	}
+	// quantities made before the reset still have the same units as the ones made after
+	if b, _ := Parse("5 km/h"); *a != *b {
+		t.Errorf("expected %v to equal %v", a, b)
+	}
+}
+
+// returns the number of entries in the intern table of units
+func internedUnits() int {
+	unitExprsMu.RLock()
+	defer unitExprsMu.RUnlock()
+	return len(unitExprs)
+}
+
+func TestInternedUnitsCollected(t *testing.T) {
+	before := internedUnits()
+	var quantities []*Qty
+	for i := 1; i <= 20; i++ {
+		for j := 1; j <= 20; j++ {
+			if q, err := Parse(fmt.Sprintf("1 furlong^%v/fortnight^%v", i, j)); err != nil {
+				t.Fatalf("failed to parse, got %v", err)
+			} else {
+				quantities = append(quantities, q)
+			}
+		}
+	}
+	if after := internedUnits(); after < before+400 {
+		t.Fatalf("expected at least %v interned units, got %v", before+400, after)
+	}
+	runtime.KeepAlive(quantities)
+
+	// the cleanups that remove collected units run after a garbage collection
+	ResetCaches()
+	deadline := time.Now().Add(5 * time.Second)
+	for internedUnits() >= before+400 && time.Now().Before(deadline) {
+		runtime.GC()
+		time.Sleep(10 * time.Millisecond)
+	}
+	if after := internedUnits(); after >= before+400 {
+		t.Errorf("expected the units to be collected, got %v interned units", after)
+	}
+}
+
+// Parses untrusted-looking input from many goroutines, run with `go test -race` to detect data races
+func TestConcurrentCaches(t *testing.T) {
+	defer SetCacheCapacity("units", CacheStats()["units"].Capacity)
+	SetCacheCapacity("units", 8)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				Parse(fmt.Sprintf("%v furlong%v", j, j))
+				if q, err := Parse(fmt.Sprintf("%v km", j)); err == nil {
+					q.To("ft")
+				}
+				CacheStats()
+			}
+		}(i)
+	}
+	wg.Wait()
+	if stats := CacheStats()["units"]; stats.Size > 8 {
+		t.Errorf("expected at most 8 units, got %+v", stats)
+	}
+}
@@ -2,7 +2,7 @@ package goqty
 
 import "fmt"
 
-func (q *Qty) Eq(other interface{}) (bool, error) {
+func (q Qty) Eq(other interface{}) (bool, error) {
 	if i, err := q.CompareTo(other); err != nil {
 		return false, err
 	} else if i == 0 {
@@ -11,7 +11,7 @@ func (q *Qty) Eq(other interface{}) (bool, error) {
 		return false, nil
 	}
 }
-func (q *Qty) Lt(other interface{}) (bool, error) {
+func (q Qty) Lt(other interface{}) (bool, error) {
 	if i, err := q.CompareTo(other); err != nil {
 		return false, err
 	} else if i == -1 {
@@ -20,7 +20,7 @@ func (q *Qty) Lt(other interface{}) (bool, error) {
 		return false, nil
 	}
 }
-func (q *Qty) Lte(other interface{}) (bool, error) {
+func (q Qty) Lte(other interface{}) (bool, error) {
 	if eq, err := q.Eq(other); err != nil {
 		return false, err
 	} else if lt, err := q.Lt(other); err != nil {
@@ -29,7 +29,7 @@ func (q *Qty) Lte(other interface{}) (bool, error) {
 		return eq || lt, nil
 	}
 }
-func (q *Qty) Gt(other interface{}) (bool, error) {
+func (q Qty) Gt(other interface{}) (bool, error) {
 	if i, err := q.CompareTo(other); err != nil {
 		return false, err
 	} else if i == 1 {
@@ -38,7 +38,7 @@ func (q *Qty) Gt(other interface{}) (bool, error) {
 		return false, nil
 	}
 }
-func (q *Qty) Gte(other interface{}) (bool, error) {
+func (q Qty) Gte(other interface{}) (bool, error) {
 	if eq, err := q.Eq(other); err != nil {
 		return false, err
 	} else if gt, err := q.Gt(other); err != nil {
@@ -59,12 +59,14 @@ func (q *Qty) Gte(other interface{}) (bool, error) {
 //	Since "10S" == ".1ohm" (10 > .1) and "10ohm" == ".1S" (10 > .1)
 //	  Qty("10S").Inverse().CompareTo(Parse("10ohm")) == -1
 //	  Qty("10ohm").Inverse().CompareTo(Parse("10S")) == -1
-func (q *Qty) CompareTo(other interface{}) (int, error) {
+func (q Qty) CompareTo(other interface{}) (int, error) {
 	var o *Qty
 	var err error
 	switch t := other.(type) {
 	case *Qty:
 		o = other.(*Qty)
+	case Qty:
+		o = &t
 	case string:
 		if o, err = Parse(other.(string)); err != nil {
 			return 0, err
@@ -88,12 +90,14 @@ func (q *Qty) CompareTo(other interface{}) (int, error) {
 // Return true if quantities and units match
 // Unit("100 cm").Same(Unit("100 cm"))  # => true
 // Unit("100 cm").Same(Unit("1 m"))     # => false
-func (q *Qty) Same(other interface{}) (bool, error) {
+func (q Qty) Same(other interface{}) (bool, error) {
 	var o *Qty
 	var err error
 	switch t := other.(type) {
 	case *Qty:
 		o = other.(*Qty)
+	case Qty:
+		o = &t
 	case string:
 		if o, err = Parse(other.(string)); err != nil {
 			return false, err
@@ -101,5 +105,5 @@ func (q *Qty) Same(other interface{}) (bool, error) {
 	default:
 		return false, fmt.Errorf("expecting string or *Qty, got %T", t)
 	}
-	return (q.scalar == o.scalar) && (q.units == o.units), nil
+	return (q.scalar == o.scalar) && (q.Units() == o.Units()), nil
 }
@@ -0,0 +1,92 @@
+package goqty
+
+import (
+	"sync"
+	"testing"
+)
+
+// Exercises a shared *Qty from many goroutines, run with `go test -race` to detect data races
+func TestConcurrentReads(t *testing.T) {
+	limits := map[string]*Qty{}
+	for _, expr := range []string{"100 km/h", "2.5 MPa", "37 tempC", "10 dBm", "10 psig", "1.5 kg*m/s^2"} {
+		if q, err := Parse(expr); err != nil {
+			t.Fatalf("failed to parse %v, got %v", expr, err)
+		} else {
+			limits[expr] = q
+		}
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				for _, q := range limits {
+					_ = q.Units()
+					_ = q.String()
+					_ = q.Kind()
+					_ = q.IsBase()
+					_ = q.IsUnitless()
+					_ = q.Numerator()
+					_ = q.Denominator()
+					if _, err := q.ToBase(); err != nil {
+						t.Errorf("failed to convert %v to base, got %v", q, err)
+					}
+					if _, err := q.To(q.Units()); err != nil {
+						t.Errorf("failed to convert %v, got %v", q, err)
+					}
+					// levels can't be scaled
+					if _, err := q.Mul(2.0); err != nil && !q.IsLevel() {
+						t.Errorf("failed to multiply %v, got %v", q, err)
+					}
+					if _, err := q.CompareTo(q); err != nil {
+						t.Errorf("failed to compare %v, got %v", q, err)
+					}
+				}
+				speed := limits["100 km/h"]
+				if _, err := speed.Add("5 m/s"); err != nil {
+					t.Errorf("failed to add, got %v", err)
+				}
+				if _, err := speed.To("mph"); err != nil {
+					t.Errorf("failed to convert, got %v", err)
+				}
+				if _, err := speed.Div(limits["1.5 kg*m/s^2"]); err != nil {
+					t.Errorf("failed to divide, got %v", err)
+				}
+				temp := limits["37 tempC"]
+				if _, err := ToDegrees(temp, limits["37 tempC"]); err == nil {
+					t.Errorf("expected error converting a temperature to a temperature")
+				}
+				if _, err := temp.Sub("30 tempC"); err != nil {
+					t.Errorf("failed to subtract, got %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if s := limits["100 km/h"].String(); s != "100 km/h" {
+		t.Errorf("expected shared quantity to be unchanged, got %v", s)
+	}
+}
+
+func TestConversionDoesNotReturnReceiver(t *testing.T) {
+	q, err := Parse("12 in")
+	if err != nil {
+		t.Fatalf("failed to parse, got %v", err)
+	}
+	if c, err := q.To("in"); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else if c == q {
+		t.Errorf("expected a new quantity, got the receiver")
+	}
+
+	src, _ := Parse("100 tempC")
+	dst, _ := Parse("5 degF")
+	if _, err := ToDegrees(src, dst); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else if dst.scalar != 5 {
+		t.Errorf("expected destination to be unchanged, got %v", dst)
+	}
+}
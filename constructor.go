@@ -1,94 +1,232 @@
 package goqty
 
 import (
+	"encoding/binary"
 	"fmt"
+	"runtime"
 	"slices"
-	"strings"
+	"sync"
+	"weak"
 )
 
+// Qty is a quantity: a scalar value with units.
+//
+// Qty is a value type.  The zero value is a unitless 0, and quantities can be stored by value in structs and maps
+// and compared with ==, which is true when both the scalars and the units are the same.
+// Constructors return *Qty and all methods can be called on both Qty and *Qty.
+//
+// A Qty is immutable once it has been constructed; every operation returns a new Qty
+// and never modifies its receiver or its arguments.
+// It is therefore safe to share a Qty or *Qty between goroutines without synchronization.
 type Qty struct {
-	scalar      float64
-	baseScalar  float64
-	numerator   []string
-	denominator []string
-	units       string
-	signature   int
-	isBase      int
+	scalar     float64
+	baseScalar float64
+	expr       *unitExpr // nil when unitless
 }
 
-func newQty(scalar float64, numerator []string, denominator []string) (*Qty, error) {
-	result := Qty{
-		scalar:      scalar,
-		numerator:   numerator,
-		denominator: denominator,
+// unitExpr holds the units of a quantity and everything derived from them.
+// unitExprs are interned so that all quantities with the same units share one,
+// which keeps Qty small and comparable.
+// The intern table only holds weak pointers, so units that no quantity, unit or cache refers to any more are collected.
+type unitExpr struct {
+	num       []term
+	den       []term
+	units     string
+	dimension Dimension
+	isBase    bool
+	affine    bool
+	level     bool
+	factor    float64   // the size of one of these units in base units, for linear units
+	base      *unitExpr // the base units, for linear units
+}
+
+var unitExprsMu sync.RWMutex
+var unitExprs = make(map[string]weak.Pointer[unitExpr])
+
+// returns the interned unitExpr for the terms, or nil if unitless
+func internUnits(num, den []term) (*unitExpr, error) {
+	if len(num) == 0 && len(den) == 0 {
+		return nil, nil
+	}
+
+	// the order of the terms doesn't matter, so m*s and s*m intern to the same expression
+	num, den = sortedTerms(num), sortedTerms(den)
+	var buf [64]byte
+	key := binary.AppendUvarint(buf[:0], uint64(len(num)))
+	key = appendTermsKey(key, num)
+	key = appendTermsKey(key, den)
+	unitExprsMu.RLock()
+	cached := unitExprs[string(key)].Value()
+	unitExprsMu.RUnlock()
+	if cached != nil {
+		return cached, nil
 	}
-	if len(numerator) == 0 {
-		result.numerator = unityArray
+
+	e := &unitExpr{
+		num:       num,
+		den:       den,
+		dimension: termsDimension(num, den),
+		isBase:    computeIsBase(num, den),
+		affine:    isAffine(num, den),
+		level:     isLevel(num, den),
+		factor:    1,
+	}
+	e.units = stringifyTerms(num, den)
+	e.base = e
+	if !e.isBase && !e.affine && !e.level {
+		if base, err := toBaseUnits(num, den); err != nil {
+			return nil, err
+		} else {
+			e.factor = base.baseScalar
+			if base.expr != nil {
+				e.base = base.expr.base
+			} else {
+				e.base = nil
+			}
+		}
 	}
-	if len(denominator) == 0 {
-		result.denominator = unityArray
+
+	unitExprsMu.Lock()
+	defer unitExprsMu.Unlock()
+	if actual := unitExprs[string(key)].Value(); actual != nil {
+		return actual, nil
 	}
+	unitExprs[string(key)] = weak.Make(e)
+	runtime.AddCleanup(e, removeUnitExpr, string(key))
+	return e, nil
+}
 
-	// math with temperatures is very limited
-	if strings.Contains(strings.Join(result.denominator, "*"), "temp") {
-		return nil, fmt.Errorf("cannot divide with temperatures")
+// removes the intern table entry of a collected unitExpr, unless the units were interned again since
+func removeUnitExpr(key string) {
+	unitExprsMu.Lock()
+	defer unitExprsMu.Unlock()
+	if unitExprs[key].Value() == nil {
+		delete(unitExprs, key)
 	}
-	if strings.Contains(strings.Join(result.numerator, "*"), "temp") {
-		if len(result.numerator) > 1 {
-			return nil, fmt.Errorf("cannot divide with temperatures")
+}
+
+func newQty(scalar float64, numerator []string, denominator []string) (*Qty, error) {
+	return newQtyTerms(scalar, termsOf(numerator, unitIDs), termsOf(denominator, unitIDs))
+}
+
+func newQtyTerms(scalar float64, num, den []term) (*Qty, error) {
+	// math with affine units (eg temperatures) is very limited
+	for _, t := range den {
+		if unitInfos[t.unit].affine {
+			return nil, fmt.Errorf("cannot divide with %v", affineNoun(unitInfos[t.unit].name))
 		}
-		if slices.Compare(result.denominator, unityArray) != 0 {
-			return nil, fmt.Errorf("cannot divide with temperatures")
+	}
+	for _, t := range num {
+		if unitInfos[t.unit].affine && (len(num) > 1 || len(den) > 0 || t.prefix != noUnit || t.power > 1) {
+			return nil, fmt.Errorf("cannot divide with %v", affineNoun(unitInfos[t.unit].name))
 		}
 	}
 
-	if err := result.updateBaseScalar(); err != nil {
+	if slices.ContainsFunc(num, largePower) || slices.ContainsFunc(den, largePower) {
+		return nil, fmt.Errorf("unit power is larger than %v", maxPower)
+	}
+
+	// logarithmic levels can't be combined with other units
+	if !isLevel(num, den) && (containsLevel(num) || containsLevel(den)) {
+		return nil, fmt.Errorf("cannot combine logarithmic levels with other units")
+	}
+
+	if expr, err := internUnits(num, den); err != nil {
+		return nil, err
+	} else {
+		return newQtyExpr(scalar, expr)
+	}
+}
+
+// returns a quantity with units that have already been validated and interned
+func newQtyExpr(scalar float64, expr *unitExpr) (*Qty, error) {
+	result := Qty{scalar: scalar, expr: expr}
+	var err error
+	if result.baseScalar, err = result.computeBaseScalar(); err != nil {
 		return nil, err
 	}
 
-	if result.IsTemperature() && result.baseScalar < 0 {
-		return nil, fmt.Errorf("temperatures must not be less than absolute zero")
+	if result.IsAffine() && result.baseScalar < 0 {
+		return nil, fmt.Errorf("%v must not be less than absolute zero", affineNoun(result.singleUnit()))
 	}
 	return &result, nil
+}
 
+// returns a quantity with the same units and another scalar
+func (q Qty) withScalar(scalar float64) (*Qty, error) {
+	return newQtyExpr(scalar, q.expr)
 }
-func New(scalar float64, units string) (*Qty, error) {
-	if units != "" {
-		if q, err := Parse(units); err != nil {
-			return nil, err
+
+// Creates a quantity given a scalar and units as a string or Unit, e.g. New(1.5, "m")
+func New(scalar float64, units interface{}) (*Qty, error) {
+	switch t := units.(type) {
+	case Unit:
+		return newQtyExpr(scalar, t.expr)
+	case string:
+		if t != "" {
+			if q, err := Parse(t); err != nil {
+				return nil, err
+			} else {
+				return newQtyExpr(scalar, q.expr)
+			}
 		} else {
-			return newQty(scalar, q.numerator, q.denominator)
+			return newQty(scalar, unityArray, unityArray)
 		}
-	} else {
-		return newQty(scalar, unityArray, unityArray)
+	default:
+		return nil, fmt.Errorf("expecting string or Unit, got %T", t)
 	}
 }
 
-func (q *Qty) Scalar() float64 {
+func (q Qty) Scalar() float64 {
 	return q.scalar
 }
-func (q *Qty) Numerator() []string {
-	return q.numerator
+
+// Returns the unit tokens of the numerator, with a token for every power, e.g. <meter>,<meter> for m^2
+func (q Qty) Numerator() []string {
+	return tokensOf(q.numTerms())
+}
+
+// Returns the unit tokens of the denominator, with a token for every power, e.g. <second>,<second> for 1/s^2
+func (q Qty) Denominator() []string {
+	return tokensOf(q.denTerms())
+}
+
+// returns the name of the unit if the quantity has a single unit without a prefix or power, e.g. <temp-C>;
+// otherwise ""
+func (q Qty) singleUnit() string {
+	if num := q.numTerms(); len(num) == 1 && len(q.denTerms()) == 0 && num[0].prefix == noUnit && num[0].power == 1 {
+		return unitInfos[num[0].unit].name
+	}
+	return ""
 }
-func (q *Qty) Denominator() []string {
-	return q.denominator
+func (q Qty) numTerms() []term {
+	if q.expr == nil {
+		return nil
+	}
+	return q.expr.num
+}
+func (q Qty) denTerms() []term {
+	if q.expr == nil {
+		return nil
+	}
+	return q.expr.den
+}
+func (q Qty) signature() int {
+	return q.Dimension().signature()
 }
 
-func (q *Qty) updateBaseScalar() error {
-	if q.IsBase() {
-		q.baseScalar = q.scalar
-		if signature, err := q.unitSignature(); err != nil {
-			return err
+func (q Qty) computeBaseScalar() (float64, error) {
+	if q.IsAffine() {
+		return q.affineBaseScalar(), nil
+	} else if q.IsLevel() {
+		if base, err := q.levelToBase(); err != nil {
+			return 0, err
 		} else {
-			q.signature = signature
+			return base.scalar, nil
 		}
+	} else if q.expr == nil || q.expr.isBase {
+		return q.scalar, nil
 	} else {
-		if base, err := q.ToBase(); err != nil {
-			return err
-		} else {
-			q.baseScalar = base.scalar
-			q.signature = base.signature
-		}
+		return mulSafe(q.expr.factor, q.scalar), nil
 	}
-	return nil
 }
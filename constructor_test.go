@@ -1,6 +1,7 @@
 package goqty
 
 import (
+	"slices"
 	"testing"
 )
 
@@ -37,3 +38,105 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestZeroValue(t *testing.T) {
+	var q Qty
+	if !q.IsUnitless() {
+		t.Errorf("expected zero value to be unitless")
+	}
+	if q.Units() != "" {
+		t.Errorf("expected no units, got %v", q.Units())
+	}
+	if q.String() != "0" {
+		t.Errorf("expected 0, got %v", q.String())
+	}
+	if q.Kind() != "unitless" {
+		t.Errorf("expected kind unitless, got %v", q.Kind())
+	}
+	if sum, err := q.Add(Qty{}); err != nil {
+		t.Errorf("failed to add, got %v", err)
+	} else if *sum != q {
+		t.Errorf("expected %v, got %v", q, sum)
+	}
+	if n, _ := New(0, ""); *n != q {
+		t.Errorf("expected New(0, \"\") to equal the zero value")
+	}
+}
+
+func TestValueEquality(t *testing.T) {
+	tests := map[string]struct {
+		a, b     string
+		expected bool
+	}{
+		"same":           {"1 m", "1 m", true},
+		"alias":          {"1 m", "1 meter", true},
+		"different":      {"1 m", "2 m", false},
+		"different unit": {"100 cm", "1 m", false},
+		"compound":       {"5 kg*m/s^2", "5 kg*m/s^2", true},
+		"term order":     {"1 m*s", "1 s*m", true},
+		"den order":      {"1 kg/m*s", "1 kg/s*m", true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, _ := Parse(test.a)
+			b, _ := Parse(test.b)
+			if actual := *a == *b; actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+
+	ms, _ := Parse("1 m*s")
+	kg, _ := Parse("1 kg")
+	kgms, _ := Parse("1 kg*m*s")
+	if p, err := ms.Mul(kg); err != nil {
+		t.Errorf("failed to multiply, got %v", err)
+	} else if *p != *kgms {
+		t.Errorf("expected %v to equal %v", p, kgms)
+	}
+
+	a, _ := Parse("1 m")
+	b, _ := Parse("1 m")
+	counts := map[Qty]int{}
+	counts[*a]++
+	counts[*b]++
+	if len(counts) != 1 || counts[*a] != 2 {
+		t.Errorf("expected quantities to be usable as map keys, got %v", counts)
+	}
+
+	type sample struct {
+		Distance Qty
+	}
+	s := sample{Distance: *a}
+	if c, err := s.Distance.To("cm"); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else if c.String() != "100 cm" {
+		t.Errorf("expected 100 cm, got %v", c)
+	}
+}
+
+func TestInternedUnits(t *testing.T) {
+	tests := map[string]struct {
+		a, b        string
+		numerator   []string
+		denominator []string
+	}{
+		"order":    {"1 m*s*m", "1 m^2*s", []string{"<meter>", "<meter>", "<second>"}, []string{"<1>"}},
+		"prefixes": {"1 km*km/h", "1 km^2/h", []string{"<kilo>", "<meter>", "<kilo>", "<meter>"}, []string{"<hour>"}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, _ := Parse(test.a)
+			b, _ := Parse(test.b)
+			if *a != *b {
+				t.Errorf("expected %v and %v to be the same", a, b)
+			}
+			if !slices.Equal(a.Numerator(), test.numerator) {
+				t.Errorf("expected numerator %v, got %v", test.numerator, a.Numerator())
+			}
+			if !slices.Equal(a.Denominator(), test.denominator) {
+				t.Errorf("expected denominator %v, got %v", test.denominator, a.Denominator())
+			}
+		})
+	}
+}
@@ -3,99 +3,53 @@ package goqty
 import (
 	"fmt"
 	"math"
-	"sync"
 )
 
-// var conversionCache sync.Map
-var baseUnitCache sync.Map
-
-func (q *Qty) To(other interface{}) (*Qty, error) {
+func (q Qty) To(other interface{}) (*Qty, error) {
 	var o *Qty
 	var err error
 	switch t := other.(type) {
 	case *Qty:
 		o = other.(*Qty)
+	case Qty:
+		o = &t
+	case Unit:
+		o = t.one()
 	case string:
 		if o, err = Parse(other.(string)); err != nil {
 			return nil, err
 		}
 	default:
-		return nil, fmt.Errorf("expecting string or *Qty, got %T", t)
+		return nil, fmt.Errorf("expecting string, Unit or *Qty, got %T", t)
 	}
 
-	// TODO conversion cache has to be a member of Qty, not global
-	// if cached, found := conversionCache.Load(units); found {
-	// 	result := cached.(*Qty)
-	// 	return result, nil
-	// }
-
-	// Instantiating target to normalize units
-	target, err := New(1, o.Units())
-	if err != nil {
-		return target, err
-	} else if target.Units() == q.Units() {
-		return q, nil
+	if o.Units() == q.Units() {
+		return &q, nil
 	}
-
-	if !q.IsCompatible(target) {
-		if q.IsInverse(target) {
-			i, _ := q.Inverse()
-			if target, err = i.To(o); err != nil {
-				return target, err
-			}
-		} else {
-			return target, fmt.Errorf("incompatible units: %v and %v", q.Units(), target.Units())
-		}
+	if c, err := NewConverter(q.Unit(), o.Unit()); err != nil {
+		return nil, err
 	} else {
-		if target.IsTemperature() {
-			if target, err = ToTemp(q, target); err != nil {
-				return target, err
-			}
-		} else if target.IsDegrees() {
-			if target, err = ToDegrees(q, target); err != nil {
-				return target, err
-			}
-		} else {
-			if scalar, err := divSafe(q.baseScalar, target.baseScalar); err != nil {
-				return nil, err
-			} else {
-				if target, err = newQty(scalar, target.numerator, target.denominator); err != nil {
-					return nil, err
-				}
-			}
-		}
+		return c.ConvertQty(&q)
 	}
-
-	// conversionCache.Store(units, target)
-	return target, nil
 }
 
 // convert to base SI units
-// results of the conversion are cached so subsequent calls to this will be fast
-func (q *Qty) ToBase() (*Qty, error) {
+// the base units are computed once for each distinct set of units, so subsequent calls to this will be fast
+func (q Qty) ToBase() (*Qty, error) {
 	if q.IsBase() {
-		return q, nil
+		return &q, nil
 	}
-	if q.IsTemperature() {
-		return q.ToTempK()
+	if q.IsAffine() {
+		return q.affineToBase()
 	}
-
-	units := q.Units()
-	if cached, found := baseUnitCache.Load(units); found {
-		c := cached.(*Qty)
-		return c.Mul(q.scalar)
-	} else {
-		if base, err := toBaseUnits(q.numerator, q.denominator); err != nil {
-			return nil, err
-		} else {
-			baseUnitCache.Store(units, base)
-			return base.Mul(q.scalar)
-		}
+	if q.IsLevel() {
+		return q.levelToBase()
 	}
+	return &Qty{scalar: q.baseScalar, baseScalar: q.baseScalar, expr: q.expr.base}, nil
 }
 
 // Converts the unit back to a float if it is unitless.  Otherwise raises an exception
-func (q *Qty) ToFloat() (float64, error) {
+func (q Qty) ToFloat() (float64, error) {
 	if q.IsUnitless() {
 		return q.scalar, nil
 	} else {
@@ -110,23 +64,27 @@ func (q *Qty) ToFloat() (float64, error) {
 // Qty('0.8 cu').toPrec('0.25 cu'); // returns 0.75 cu
 // Qty('6.3782 m').ToPrec('cm'); // returns 6.38 m
 // Qty('1.146 MPa').ToPrec('0.1 bar'); // returns 1.15 MPa
-func (q *Qty) ToPrec(precision interface{}) (*Qty, error) {
+func (q Qty) ToPrec(precision interface{}) (*Qty, error) {
 	var p *Qty
 	var err error
 	switch t := precision.(type) {
 	case float64:
-		return newQty(mulSafe(precision.(float64), q.scalar), q.numerator, q.denominator)
+		return q.withScalar(mulSafe(precision.(float64), q.scalar))
 	case *Qty:
 		p = precision.(*Qty)
+	case Qty:
+		p = &t
+	case Unit:
+		p = t.one()
 	case string:
 		if p, err = Parse(precision.(string)); err != nil {
 			return nil, err
 		}
 	default:
-		return nil, fmt.Errorf("expected float64, string, or *Qty, got %T", t)
+		return nil, fmt.Errorf("expected float64, string, Unit or *Qty, got %T", t)
 	}
 	if !q.IsUnitless() {
-		if p, err = p.To(q.Units()); err != nil {
+		if p, err = p.To(q.Unit()); err != nil {
 			return nil, err
 		}
 	} else if !p.IsUnitless() {
@@ -139,7 +97,7 @@ func (q *Qty) ToPrec(precision interface{}) (*Qty, error) {
 
 	resultScalar := mulSafe(math.Round(q.scalar/p.scalar), p.scalar)
 
-	return New(resultScalar, q.Units())
+	return New(resultScalar, q.Unit())
 }
 
 /**
@@ -149,85 +107,63 @@ func (q *Qty) ToPrec(precision interface{}) (*Qty, error) {
  * with same units into others with iterative methods.
  * Does not take care of rounding issues.
  *
+ * Units can be given as strings or as Units.
+ * The conversion is a precomputed scale and offset, which also covers temperatures and gauge pressures.
+ * Use NewConverter with ConvertInPlace or ConvertInto to avoid allocating a result, or for []float32.
+ *
  * converter, _ := qty.SwiftConverter("m/h", "ft/s")
  * converted, _ := converter([]float64{...})
  */
-func SwiftConverter(srcUnits, dstUnits string) (converter func(values []float64) ([]float64, error), err error) {
-	var srcQty *Qty
-	var dstQty *Qty
-	if srcQty, err = Parse(srcUnits); err != nil {
+func SwiftConverter(srcUnits, dstUnits interface{}) (converter func(values []float64) ([]float64, error), err error) {
+	var srcUnit, dstUnit Unit
+	if srcUnit, err = toUnit(srcUnits); err != nil {
 		return converter, err
-	} else if dstQty, err = Parse(dstUnits); err != nil {
+	} else if dstUnit, err = toUnit(dstUnits); err != nil {
 		return converter, err
 	}
-
-	if eq, err := srcQty.Eq(dstQty); err != nil {
+	c, err := NewConverter(srcUnit, dstUnit)
+	if err != nil {
 		return converter, err
-	} else if eq {
+	}
+	if srcUnit == dstUnit {
 		return func(values []float64) ([]float64, error) {
 			return values, nil
 		}, nil
 	}
-
-	var convert func(values float64) (float64, error)
-	if !srcQty.IsTemperature() {
-		convert = func(value float64) (float64, error) {
-			return value * srcQty.baseScalar / dstQty.baseScalar, nil
-		}
-	} else {
-		convert = func(value float64) (float64, error) {
-			// TODO Not optimized
-			if q, err := srcQty.Mul(value); err != nil {
-				return value, err
-			} else {
-				if t, err := q.To(dstQty.units); err != nil {
-					return value, err
-				} else {
-					return t.scalar, nil
-				}
-			}
-		}
-	}
-
 	return func(values []float64) ([]float64, error) {
-		var result []float64
-		for _, v := range values {
-			if c, err := convert(v); err != nil {
-				return result, err
-			} else {
-				result = append(result, c)
-			}
-		}
-		return result, nil
+		return ConvertSlice(c, values)
 	}, nil
 }
 
-func toBaseUnits(numerator, denominator []string) (*Qty, error) {
-	num := []string{}
-	den := []string{}
+func toBaseUnits(numerator, denominator []term) (*Qty, error) {
+	var num, den []term
 	q := float64(1)
 
-	for _, n := range numerator {
-		if prefix, ok := prefixes[n]; ok {
+	for _, t := range numerator {
+		unit := unitInfos[t.unit]
+		if t.prefix != noUnit {
 			// workaround to fix
 			// 0.1 * 0.1 => 0.010000000000000002
-			q = mulSafe(q, prefix.scalar)
-		} else if unit, ok := units[n]; ok {
-			q *= unit.scalar
-			num = append(num, unit.numerator...)
-			den = append(den, unit.denominator...)
+			q = mulSafe(q, powSafe(unitInfos[t.prefix].scalar, t.power))
 		}
+		q *= powInt(unit.scalar, t.power)
+		num = append(num, powTerms(unit.numerator, t.power)...)
+		den = append(den, powTerms(unit.denominator, t.power)...)
 	}
 
-	for _, d := range denominator {
-		if prefix, ok := prefixes[d]; ok {
-			q /= prefix.scalar
-		} else if unit, ok := units[d]; ok {
-			q /= unit.scalar
-			den = append(den, unit.numerator...)
-			num = append(num, unit.denominator...)
+	for _, t := range denominator {
+		unit := unitInfos[t.unit]
+		if t.prefix != noUnit {
+			q /= powInt(unitInfos[t.prefix].scalar, t.power)
 		}
+		q /= powInt(unit.scalar, t.power)
+		den = append(den, powTerms(unit.numerator, t.power)...)
+		num = append(num, powTerms(unit.denominator, t.power)...)
 	}
 
-	return newQty(q, num, den)
+	if num, den, scale, err := cleanTerms(num, den, nil, nil); err != nil {
+		return nil, err
+	} else {
+		return newQtyTerms(mulSafe(q, scale), num, den)
+	}
 }
@@ -107,3 +107,53 @@ func TestSwiftConverter(t *testing.T) {
 	}
 
 }
+
+func BenchmarkTo(b *testing.B) {
+	speed, _ := Parse("60 mi/h")
+	target, _ := Parse("m/s")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := speed.To(target); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestSwiftConverterTemperature(t *testing.T) {
+	if converter, err := SwiftConverter("tempC", "tempF"); err != nil {
+		t.Errorf("failed to create converter, got %v", err)
+	} else if actual, err := converter([]float64{-40, 0, 100}); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else if expected := []float64{-40, 32, 212}; !slices.EqualFunc(actual, expected, func(a, b float64) bool {
+		return math.Abs(a-b) < 1e-9
+	}) {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+}
+
+// a million values, like a column of sensor readings
+var benchmarkValues = func() []float64 {
+	values := make([]float64, 1_000_000)
+	for i := range values {
+		values[i] = float64(i % 1000)
+	}
+	return values
+}()
+
+func BenchmarkSwiftConverter(b *testing.B) {
+	converter, _ := SwiftConverter("m/h", "ft/s")
+	for i := 0; i < b.N; i++ {
+		if _, err := converter(benchmarkValues); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSwiftConverterTemperature(b *testing.B) {
+	converter, _ := SwiftConverter("tempC", "tempF")
+	for i := 0; i < b.N; i++ {
+		if _, err := converter(benchmarkValues); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package goqty
+
+import (
+	"fmt"
+	"math"
+)
+
+// A Converter converts values from one unit to another.
+// Converters are compiled once for each pair of units and cached, so
+// NewConverter is cheap to call and the Converter can be shared between goroutines.
+//
+// converter, _ := NewConverter(Foot, Meter)
+// converter.Convert(3) // 0.9144
+type Converter struct {
+	from   Unit
+	to     Unit
+	target *Qty // one of the target unit
+	// converted = value * scale + offset, for units that can be converted with a scale and an offset
+	scale  float64
+	offset float64
+	linear bool // false for logarithmic levels and inverse units, e.g. S to ohm
+	// the smallest value in the from units that isn't below absolute zero, -Inf if there is none
+	minValue float64
+}
+
+type conversionKey struct {
+	from *unitExpr
+	to   *unitExpr
+}
+
+// compiled converters by their units
+var converters = newLRUCache[conversionKey, *Converter](1024)
+
+// Returns a Converter from one unit to another compatible or inverse unit, e.g. ft to m or S to ohm
+func NewConverter(from, to Unit) (*Converter, error) {
+	key := conversionKey{from.expr, to.expr}
+	if c, found := converters.Load(key); found {
+		return c, nil
+	}
+	if c, err := compileConverter(from, to); err != nil {
+		return nil, err
+	} else {
+		return converters.LoadOrStore(key, c), nil
+	}
+}
+
+func compileConverter(from, to Unit) (*Converter, error) {
+	src := from.one()
+	dst := to.one()
+	c := &Converter{from: from, to: to, target: dst, scale: 1, linear: true, minValue: math.Inf(-1)}
+	if src.IsAffine() {
+		c.minValue = -affineUnits[src.singleUnit()].offset
+	} else if dst.IsAffine() {
+		// linear quantities are relative to absolute zero, see toAffine
+		c.minValue = 0
+	}
+	if from == to {
+		return c, nil
+	}
+	if !src.IsCompatible(dst) {
+		if !src.IsInverse(dst) {
+			return nil, fmt.Errorf("incompatible units: %v and %v", from, to)
+		}
+		c.linear = false
+		return c, nil
+	}
+
+	// the scale and offset are computed once, so values aren't rounded like To,
+	// which computes affine units in two steps through the base unit
+	var err error
+	switch {
+	case src.IsLevel() || dst.IsLevel():
+		c.linear = false
+	case dst.IsAffine():
+		// like toAffine, relative to the absolute zero of the scale
+		u := units[dst.singleUnit()]
+		a := affineUnits[dst.singleUnit()]
+		if src.IsAffine() {
+			if c.scale, err = divSafe(units[src.singleUnit()].scalar, u.scalar); err == nil {
+				c.offset = mulSafe(affineUnits[src.singleUnit()].offset, c.scale) - a.offset
+			}
+		} else {
+			c.scale, err = divSafe(src.baseScalar, u.scalar)
+			c.offset = -a.offset
+		}
+	case src.IsAffine():
+		// like toDifference, relative to the zero point of the scale
+		c.scale, err = divSafe(units[src.singleUnit()].scalar, dst.baseScalar)
+	default:
+		c.scale, err = divSafe(src.baseScalar, dst.baseScalar)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Returns the units the converter converts from
+func (c *Converter) From() Unit {
+	return c.from
+}
+
+// Returns the units the converter converts to
+func (c *Converter) To() Unit {
+	return c.to
+}
+
+// Converts a value in the from units to the to units.
+// Like SwiftConverter it does not take care of rounding issues, so it can differ from To in the last digits,
+// e.g. 100 tempC is 212 tempF here but 211.99999999999994 tempF with To; use ConvertQty for the result of To.
+// Values that can't be converted, e.g. a negative power to dBm or a temperature below absolute zero, are NaN.
+func (c *Converter) Convert(value float64) float64 {
+	if v, err := c.convert(value); err != nil {
+		return math.NaN()
+	} else {
+		return v
+	}
+}
+
+func (c *Converter) convert(value float64) (float64, error) {
+	if c.linear {
+		if value < c.minValue {
+			return 0, c.absoluteZeroError()
+		}
+		return value*c.scale + c.offset, nil
+	}
+	if q, err := newQtyExpr(value, c.from.expr); err != nil {
+		return 0, err
+	} else if q, err = c.ConvertQty(q); err != nil {
+		return 0, err
+	} else {
+		return q.scalar, nil
+	}
+}
+
+// returns the error for a value below absolute zero, in the noun of the affine unit
+func (c *Converter) absoluteZeroError() error {
+	from := Qty{expr: c.from.expr}
+	unit := c.target.singleUnit()
+	if from.IsAffine() {
+		unit = from.singleUnit()
+	}
+	return fmt.Errorf("%v must not be less than absolute zero", affineNoun(unit))
+}
+
+// Float is the type of the values converted by ConvertSlice, ConvertInto and ConvertInPlace
+type Float interface {
+	~float32 | ~float64
+}
+
+// Converts values into a new slice, e.g. ConvertSlice(c, []float32{1, 2, 3})
+func ConvertSlice[T Float](c *Converter, values []T) ([]T, error) {
+	return ConvertInto(c, make([]T, len(values)), values)
+}
+
+// Converts values in place
+func ConvertInPlace[T Float](c *Converter, values []T) error {
+	_, err := ConvertInto(c, values, values)
+	return err
+}
+
+// Converts src into dst, which must be at least as long as src, and returns dst[:len(src)].
+// dst and src may be the same slice.
+func ConvertInto[T Float](c *Converter, dst, src []T) ([]T, error) {
+	if len(dst) < len(src) {
+		return nil, fmt.Errorf("expected at least %v values in the destination, got %v", len(src), len(dst))
+	}
+	dst = dst[:len(src)]
+	if !c.linear {
+		for i, v := range src {
+			if converted, err := c.convert(float64(v)); err != nil {
+				return nil, err
+			} else {
+				dst[i] = T(converted)
+			}
+		}
+		return dst, nil
+	}
+
+	scale, offset := c.scale, c.offset
+	if !math.IsInf(c.minValue, -1) {
+		for i, v := range src {
+			if float64(v) < c.minValue {
+				return nil, c.absoluteZeroError()
+			}
+			dst[i] = T(float64(v)*scale + offset)
+		}
+	} else if offset == 0 {
+		for i, v := range src {
+			dst[i] = T(float64(v) * scale)
+		}
+	} else {
+		for i, v := range src {
+			dst[i] = T(float64(v)*scale + offset)
+		}
+	}
+	return dst, nil
+}
+
+// Converts a quantity in the from units to the to units, like To
+func (c *Converter) ConvertQty(q *Qty) (*Qty, error) {
+	if q.expr != c.from.expr {
+		return nil, fmt.Errorf("expected %v, got %v", c.from, q.Units())
+	}
+	if q.expr == c.to.expr {
+		result := *q
+		return &result, nil
+	}
+
+	target := c.target
+	if !q.IsCompatible(target) {
+		if i, err := q.Inverse(); err != nil {
+			return nil, err
+		} else {
+			return i.To(c.to)
+		}
+	} else if target.IsLevel() {
+		return toLevel(q, target)
+	} else if target.IsAffine() {
+		return toAffine(q, target)
+	} else if q.IsAffine() {
+		return toDifference(q, target)
+	} else if scalar, err := divSafe(q.baseScalar, target.baseScalar); err != nil {
+		return nil, err
+	} else {
+		return target.withScalar(scalar)
+	}
+}
@@ -0,0 +1,243 @@
+package goqty
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestConverter(t *testing.T) {
+	tests := map[string]struct {
+		from     string
+		to       string
+		value    float64
+		expected float64
+	}{
+		"same":                {"m", "m", 3, 3},
+		"linear":              {"ft", "m", 3, 0.9144},
+		"compound":            {"mi/h", "m/s", 60, 26.8224},
+		"temperature":         {"tempC", "tempF", 100, 212},
+		"temperature to abs":  {"tempF", "tempK", 32, 273.15},
+		"difference to temp":  {"degC", "tempC", 300, 26.85},
+		"temp to difference":  {"tempC", "degF", 10, 18},
+		"gauge pressure":      {"psig", "psia", 0, 101325 / 6894.76},
+		"inverse":             {"ohm", "S", 10, 0.1},
+		"level":               {"dBm", "mW", 10, 10},
+		"level from linear":   {"W", "dBm", 1, 30},
+		"prefixed difference": {"kelvin", "degC", 5, 5},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			from, _ := ParseUnit(test.from)
+			to, _ := ParseUnit(test.to)
+			c, err := NewConverter(from, to)
+			if err != nil {
+				t.Errorf("failed to create converter, got %v", err)
+				return
+			}
+			if actual := c.Convert(test.value); math.Abs(actual-test.expected) > 1e-6 {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+			q, _ := New(test.value, from)
+			if actual, err := c.ConvertQty(q); err != nil {
+				t.Errorf("failed to convert %v, got %v", q, err)
+			} else if expected, _ := q.To(to); *actual != *expected {
+				t.Errorf("expected %v, got %v", expected, actual)
+			}
+		})
+	}
+}
+
+func TestConverterFailure(t *testing.T) {
+	tests := map[string]struct {
+		from     string
+		to       string
+		expected string
+	}{
+		"incompatible": {"m", "s", "incompatible units: m and s"},
+		"level":        {"dBm", "m", "incompatible units: dBm and m"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			from, _ := ParseUnit(test.from)
+			to, _ := ParseUnit(test.to)
+			if c, err := NewConverter(from, to); err == nil {
+				t.Errorf("expected error %v, got %v", test.expected, c)
+			} else if err.Error() != test.expected {
+				t.Errorf("expected error %v, got %v", test.expected, err)
+			}
+		})
+	}
+
+	c, _ := NewConverter(Foot, Meter)
+	if q, err := c.ConvertQty(&Qty{scalar: 1, expr: Inch.expr}); err == nil {
+		t.Errorf("expected error converting inches with a foot converter, got %v", q)
+	}
+	c, _ = NewConverter(Watt, Milli(Watt))
+	if v := c.Convert(-1); v != -1000 {
+		t.Errorf("expected -1000, got %v", v)
+	}
+	c, _ = NewConverter(Watt, DecibelMilliwatt)
+	if v := c.Convert(-1); !math.IsNaN(v) {
+		t.Errorf("expected NaN for a negative power level, got %v", v)
+	}
+}
+
+func TestConverterAbsoluteZero(t *testing.T) {
+	tests := map[string]struct {
+		from  string
+		to    string
+		value float64
+	}{
+		"temperature":        {"tempC", "tempF", -300},
+		"same":               {"tempC", "tempC", -300},
+		"difference to temp": {"degC", "tempC", -5},
+		"temp to difference": {"tempF", "degF", -500},
+		"gauge pressure":     {"psig", "psia", -20},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			from, _ := ParseUnit(test.from)
+			to, _ := ParseUnit(test.to)
+			c, err := NewConverter(from, to)
+			if err != nil {
+				t.Errorf("failed to create converter, got %v", err)
+				return
+			}
+			if v := c.Convert(test.value); !math.IsNaN(v) {
+				t.Errorf("expected NaN below absolute zero, got %v", v)
+			}
+			if v, err := ConvertSlice(c, []float64{0, test.value}); err == nil {
+				t.Errorf("expected error below absolute zero, got %v", v)
+			}
+		})
+	}
+
+	c, _ := NewConverter(TempC, TempK)
+	if v := c.Convert(-273.15); v != 0 {
+		t.Errorf("expected 0, got %v", v)
+	}
+	if _, err := ConvertSlice(c, []float64{-300}); err == nil || err.Error() != "temperatures must not be less than absolute zero" {
+		t.Errorf("expected error temperatures must not be less than absolute zero, got %v", err)
+	}
+}
+
+func TestConvertQtySameUnits(t *testing.T) {
+	c, _ := NewConverter(Meter, Meter)
+	q, _ := New(5, Meter)
+	if actual, err := c.ConvertQty(q); err != nil {
+		t.Errorf("failed to convert %v, got %v", q, err)
+	} else if actual == q {
+		t.Errorf("expected a new quantity, got the argument")
+	} else if *actual != *q {
+		t.Errorf("expected %v, got %v", q, actual)
+	}
+}
+
+// Convert doesn't round like To, but ConvertQty gives the result of To
+func TestConvertAffineLikeTo(t *testing.T) {
+	tempC, _ := ParseUnit("tempC")
+	tempF, _ := ParseUnit("tempF")
+	c, _ := NewConverter(tempC, tempF)
+	for _, v := range []float64{-40, 0, 37, 100} {
+		q, _ := New(v, tempC)
+		expected, err := q.To(tempF)
+		if err != nil {
+			t.Fatalf("failed to convert %v, got %v", q, err)
+		}
+		if actual := c.Convert(v); math.Abs(actual-expected.Scalar()) > 1e-9 {
+			t.Errorf("expected %v, got %v", expected.Scalar(), actual)
+		}
+		if actual, err := c.ConvertQty(q); err != nil || *actual != *expected {
+			t.Errorf("expected %v, got %v %v", expected, actual, err)
+		}
+	}
+}
+
+func TestConverterCache(t *testing.T) {
+	before := CacheStats()["converters"]
+	furlong, _ := ParseUnit("furlong/fortnight")
+	speed, _ := Meter.Div(Second)
+	if _, err := NewConverter(furlong, speed); err != nil {
+		t.Errorf("failed to create converter, got %v", err)
+	}
+	if _, err := NewConverter(Foot, Inch); err != nil {
+		t.Errorf("failed to create converter, got %v", err)
+	}
+	if _, err := NewConverter(Foot, Inch); err != nil {
+		t.Errorf("failed to create converter, got %v", err)
+	}
+	after := CacheStats()["converters"]
+	if after.Hits <= before.Hits {
+		t.Errorf("expected a hit, got %+v then %+v", before, after)
+	}
+	if after.Misses <= before.Misses {
+		t.Errorf("expected a miss, got %+v then %+v", before, after)
+	}
+	if after.Size == 0 || after.Size > after.Capacity {
+		t.Errorf("expected the size to be within 1 and %v, got %v", after.Capacity, after.Size)
+	}
+}
+
+func BenchmarkConvert(b *testing.B) {
+	c, _ := NewConverter(Foot, Meter)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.Convert(float64(i))
+	}
+}
+
+func TestConvertSlices(t *testing.T) {
+	c, _ := NewConverter(TempC, TempF)
+
+	values := []float32{-40, 0, 100}
+	if err := ConvertInPlace(c, values); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else if !slices.Equal(values, []float32{-40, 32, 212}) {
+		t.Errorf("expected [-40 32 212], got %v", values)
+	}
+
+	dst := make([]float64, 5)
+	if actual, err := ConvertInto(c, dst, []float64{0, 100}); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else if !slices.Equal(actual, []float64{32, 212}) {
+		t.Errorf("expected [32 212], got %v", actual)
+	}
+	if _, err := ConvertInto(c, dst[:1], []float64{0, 100}); err == nil {
+		t.Errorf("expected error for a short destination")
+	}
+
+	level, _ := NewConverter(Watt, DecibelMilliwatt)
+	if actual, err := ConvertSlice(level, []float64{0.001, 1}); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else if math.Abs(actual[0]) > 1e-9 || math.Abs(actual[1]-30) > 1e-9 {
+		t.Errorf("expected [0 30], got %v", actual)
+	}
+	if _, err := ConvertSlice(level, []float64{1, -1}); err == nil {
+		t.Errorf("expected error for a negative power level")
+	}
+}
+
+func BenchmarkConvertInPlace(b *testing.B) {
+	c, _ := NewConverter(TempC, TempF)
+	values := slices.Clone(benchmarkValues)
+	for i := 0; i < b.N; i++ {
+		if err := ConvertInPlace(c, values); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkConvertIntoFloat32(b *testing.B) {
+	c, _ := NewConverter(Foot, Meter)
+	src := make([]float32, len(benchmarkValues))
+	for i, v := range benchmarkValues {
+		src[i] = float32(v)
+	}
+	dst := make([]float32, len(src))
+	for i := 0; i < b.N; i++ {
+		if _, err := ConvertInto(c, dst, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -5,7 +5,7 @@ import (
 	"slices"
 )
 
-type Unit struct {
+type unitDef struct {
 	kind        string
 	scalar      float64
 	aliases     []string
@@ -18,15 +18,15 @@ type Unit struct {
 // 	unit   string
 // }
 
-func makeUnit(kind string, aliases []string, scalar float64, numerator []string, denominator []string) Unit {
-	return Unit{kind, scalar, aliases, numerator, denominator}
+func makeUnit(kind string, aliases []string, scalar float64, numerator []string, denominator []string) unitDef {
+	return unitDef{kind, scalar, aliases, numerator, denominator}
 }
 
 var unity = "<1>"
 var unityArray = []string{unity}
 var unityUnit = makeUnit("", []string{"1", "<1>"}, 1, nil, nil)
 
-var prefixes = map[string]Unit{
+var prefixes = map[string]unitDef{
 	// prefixes
 	"<googol>": makeUnit("prefix", []string{"googol"}, 1e100, nil, nil),
 	"<kibi>":   makeUnit("prefix", []string{"Ki", "Kibi", "kibi"}, math.Pow(2, 10), nil, nil),
@@ -60,7 +60,7 @@ var prefixes = map[string]Unit{
 }
 var prefixesByAlias = makeUnitAliasMap(prefixes)
 
-var units = map[string]Unit{
+var units = map[string]unitDef{
 	"<1>": unityUnit,
 
 	// length
@@ -171,6 +171,13 @@ var units = map[string]Unit{
 	"<cmh2o>":  makeUnit("pressure", []string{"cmH2O", "cmh2o"}, 98.0638, []string{"<kilogram>"}, []string{"<meter>", "<second>", "<second>"}),
 	"<inh2o>":  makeUnit("pressure", []string{"inH2O", "inh2o"}, 249.082052, []string{"<kilogram>"}, []string{"<meter>", "<second>", "<second>"}),
 
+	// absolute and gauge pressure
+	"<pascal-abs>": makeUnit("pressure", []string{"Pa-abs", "pascal-abs"}, 1.0, []string{"<pascal-abs>"}, nil),
+	"<psia>":       makeUnit("pressure", []string{"psia"}, 6894.76, []string{"<pascal-abs>"}, nil),
+	"<psig>":       makeUnit("pressure", []string{"psig"}, 6894.76, []string{"<pascal-abs>"}, nil),
+	"<bara>":       makeUnit("pressure", []string{"bara"}, 100000, []string{"<pascal-abs>"}, nil),
+	"<barg>":       makeUnit("pressure", []string{"barg"}, 100000, []string{"<pascal-abs>"}, nil),
+
 	// viscosity
 	"<poise>":  makeUnit("viscosity", []string{"P", "poise"}, 0.1, []string{"<kilogram>"}, []string{"<meter>", "<second>"}),
 	"<stokes>": makeUnit("viscosity", []string{"St", "stokes"}, 1e-4, []string{"<meter>", "<meter>"}, []string{"<second>"}),
@@ -303,12 +310,124 @@ var units = map[string]Unit{
 
 	// logarithmic
 	"<decibel>": makeUnit("logarithmic", []string{"dB", "decibel", "decibels"}, 1.0, []string{"<decibel>"}, nil),
+	"<bel>":     makeUnit("logarithmic", []string{"bel", "bels"}, 10.0, []string{"<decibel>"}, nil),
+	"<neper>":   makeUnit("logarithmic", []string{"Np", "neper", "nepers"}, 20.0/math.Ln10, []string{"<decibel>"}, nil),
+
+	// logarithmic levels, see levels for their reference values
+	"<decibel-milliwatt>": makeUnit("logarithmic", []string{"dBm", "dBmW"}, 1.0, []string{"<decibel-milliwatt>"}, nil),
+	"<decibel-watt>":      makeUnit("logarithmic", []string{"dBW"}, 1.0, []string{"<decibel-watt>"}, nil),
+	"<decibel-volt>":      makeUnit("logarithmic", []string{"dBV"}, 1.0, []string{"<decibel-volt>"}, nil),
+	"<decibel-microvolt>": makeUnit("logarithmic", []string{"dB\u00B5V", "dB\u03BCV", "dBuV"}, 1.0, []string{"<decibel-microvolt>"}, nil),
+	"<decibel-spl>":       makeUnit("logarithmic", []string{"dBSPL", "dB-SPL"}, 1.0, []string{"<decibel-spl>"}, nil),
 }
 var unitsByAlias = makeUnitAliasMap(units)
 
+// An affine unit measures on an absolute scale whose zero point is offset from the zero of its base unit,
+// e.g. tempC is offset 273.15 from tempK.  The base unit is the numerator of the unit definition,
+// and the unit definition scalar is the scale relative to the base unit.
+// Differences between two affine quantities are expressed in the paired (linear) difference unit.
+type affine struct {
+	offset     float64 // the offset of the zero point in units of this scale
+	difference string  // the unit used for differences on this scale, e.g. <celsius>
+}
+
+var affineUnits = map[string]affine{
+	"<temp-K>":     {0, "<kelvin>"},
+	"<temp-C>":     {273.15, "<celsius>"},
+	"<temp-F>":     {459.67, "<fahrenheit>"},
+	"<temp-R>":     {0, "<rankine>"},
+	"<pascal-abs>": {0, "<pascal>"},
+	"<psia>":       {0, "<psi>"},
+	"<psig>":       {101325 / 6894.76, "<psi>"},
+	"<bara>":       {0, "<bar>"},
+	"<barg>":       {1.01325, "<bar>"},
+}
+
+// A logarithmic level is expressed in decibels relative to a reference quantity.
+// Power quantities use a factor of 10 and field (root-power) quantities use a factor of 20.
+type level struct {
+	factor      float64
+	scalar      float64
+	numerator   []string
+	denominator []string
+}
+
+var levels = map[string]level{
+	"<decibel-milliwatt>": {10, 1, []string{"<milli>", "<watt>"}, nil},
+	"<decibel-watt>":      {10, 1, []string{"<watt>"}, nil},
+	"<decibel-volt>":      {20, 1, []string{"<volt>"}, nil},
+	"<decibel-microvolt>": {20, 1, []string{"<micro>", "<volt>"}, nil},
+	"<decibel-spl>":       {20, 20, []string{"<micro>", "<pascal>"}, nil},
+}
+
+// The long names of units whose name is an abbreviation or a code, e.g. dBm.
+// Every other unit's name is also its long name, e.g. <meter> is meter.
+var longNames = map[string]string{
+	"<AMU>":               "atomic mass unit",
+	"<AU>":                "astronomical unit",
+	"<Ah>":                "ampere-hour",
+	"<Bps>":               "byte per second",
+	"<Calorie>":           "kilocalorie",
+	"<Gal>":               "gal",
+	"<Wh>":                "watt-hour",
+	"<atm>":               "atmosphere",
+	"<bara>":              "bar absolute",
+	"<barg>":              "bar gauge",
+	"<base-pair>":         "base pair",
+	"<beerbarrel>":        "beer barrel",
+	"<beerbarrel-imp>":    "imperial beer barrel",
+	"<bpm>":               "beat per minute",
+	"<bps>":               "bit per second",
+	"<btu>":               "British thermal unit",
+	"<cmh2o>":             "centimeter of water",
+	"<cpm>":               "count per minute",
+	"<datamile>":          "data mile",
+	"<decibel-microvolt>": "decibel relative to one microvolt",
+	"<decibel-milliwatt>": "decibel relative to one milliwatt",
+	"<decibel-spl>":       "decibel sound pressure level",
+	"<decibel-volt>":      "decibel relative to one volt",
+	"<decibel-watt>":      "decibel relative to one watt",
+	"<dpi>":               "dot per inch",
+	"<dpm>":               "disintegration per minute",
+	"<elementary-charge>": "elementary charge",
+	"<fluid-ounce>":       "fluid ounce",
+	"<fluid-ounce-imp>":   "imperial fluid ounce",
+	"<fps>":               "foot per second",
+	"<gallon-imp>":        "imperial gallon",
+	"<gee>":               "standard gravity",
+	"<inHg>":              "inch of mercury",
+	"<inh2o>":             "inch of water",
+	"<kph>":               "kilometer per hour",
+	"<metric-ton>":        "metric ton",
+	"<mmHg>":              "millimeter of mercury",
+	"<mph>":               "mile per hour",
+	"<naut-mile>":         "nautical mile",
+	"<oilbarrel>":         "oil barrel",
+	"<pascal-abs>":        "pascal absolute",
+	"<pint-imp>":          "imperial pint",
+	"<ppb>":               "part per billion",
+	"<ppi>":               "pixel per inch",
+	"<ppm>":               "part per million",
+	"<ppq>":               "part per quadrillion",
+	"<ppt>":               "part per trillion",
+	"<psi>":               "pound per square inch",
+	"<psia>":              "pound per square inch absolute",
+	"<psig>":              "pound per square inch gauge",
+	"<rpm>":               "revolution per minute",
+	"<short-ton>":         "short ton",
+	"<sqft>":              "square foot",
+	"<temp-C>":            "degree Celsius",
+	"<temp-F>":            "degree Fahrenheit",
+	"<temp-K>":            "kelvin",
+	"<temp-R>":            "degree Rankine",
+	"<therm-US>":          "US therm",
+	"<unit>":              "enzyme unit",
+	"<wtpercent>":         "weight percent",
+}
+
 // var valuesByUnitAlias = makeUnitValuesMap(units)
 var outputs = makeOutputsMap(units)
-var baseUnits = []string{"<meter>", "<kilogram>", "<second>", "<mole>", "<ampere>", "<radian>", "<kelvin>", "<temp-K>", "<byte>", "<dollar>", "<candela>", "<each>", "<steradian>", "<decibel>"}
+var baseUnits = []string{"<meter>", "<kilogram>", "<second>", "<mole>", "<ampere>", "<radian>", "<kelvin>", "<temp-K>", "<pascal-abs>", "<byte>", "<dollar>", "<candela>", "<each>", "<steradian>", "<decibel>"}
 
 // /**
 //  * Asserts unit definition is valid
@@ -374,7 +493,7 @@ func PrefixAliases(prefix string) []string {
 	return nil
 }
 
-func makeUnitAliasMap(units map[string]Unit) map[string]string {
+func makeUnitAliasMap(units map[string]unitDef) map[string]string {
 	result := make(map[string]string)
 	for name, unit := range units {
 		for _, alias := range unit.aliases {
@@ -383,7 +502,7 @@ func makeUnitAliasMap(units map[string]Unit) map[string]string {
 	}
 	return result
 }
-func makeOutputsMap(units map[string]Unit) map[string]string {
+func makeOutputsMap(units map[string]unitDef) map[string]string {
 	result := make(map[string]string)
 	for name, unit := range units {
 		result[name] = unit.aliases[0]
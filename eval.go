@@ -0,0 +1,402 @@
+package goqty
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// A Function can be called by name from an expression passed to Eval, e.g. sqrt(9 m^2)
+type Function func(args ...*Qty) (*Qty, error)
+
+var functionsMu sync.RWMutex
+var evalFunctions = map[string]Function{
+	"sqrt": func(args ...*Qty) (*Qty, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("sqrt expects 1 argument, got %v", len(args))
+		}
+		return args[0].Sqrt()
+	},
+	"abs": func(args ...*Qty) (*Qty, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("abs expects 1 argument, got %v", len(args))
+		}
+		if args[0].scalar < 0 {
+			return args[0].Mul(-1.0)
+		}
+		return args[0], nil
+	},
+	"min": func(args ...*Qty) (*Qty, error) {
+		return extreme("min", args, -1)
+	},
+	"max": func(args ...*Qty) (*Qty, error) {
+		return extreme("max", args, 1)
+	},
+}
+
+// returns the smallest (sign -1) or the largest (sign 1) of the arguments
+func extreme(name string, args []*Qty, sign int) (*Qty, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%v expects at least 1 argument", name)
+	}
+	result := args[0]
+	for _, arg := range args[1:] {
+		if c, err := arg.CompareTo(result); err != nil {
+			return nil, err
+		} else if c == sign {
+			result = arg
+		}
+	}
+	return result, nil
+}
+
+// Registers a function that can be called by name from an expression passed to Eval.
+// Registering a function with the name of an existing function replaces it.
+func RegisterFunction(name string, fn Function) {
+	functionsMu.Lock()
+	defer functionsMu.Unlock()
+	evalFunctions[name] = fn
+}
+
+func lookupFunction(name string) (Function, bool) {
+	functionsMu.RLock()
+	defer functionsMu.RUnlock()
+	fn, ok := evalFunctions[name]
+	return fn, ok
+}
+
+/* Evaluates an arithmetic expression of quantities, e.g.
+ * "(3 m + 20 cm) * 2 / 4 s"
+ * "sqrt(9 m^2)"
+ * "max(a, b) - 5 %"
+ *
+ * Expressions can use + - * / ^ (or **), parentheses, unary minus, function calls,
+ * and the named variables in vars, which take precedence over units with the same name.
+ * A number or variable directly followed by a unit binds tighter than * and /,
+ * so 2 / 4 s is 2 / (4 s).  Exponents must be unitless integers.
+ * A number followed by a unit is a quantity like Parse makes, so levels and temperatures can be written, e.g. 3 dBm,
+ * and units can use the superscripts and multiplication signs Parse accepts, e.g. m² or N·m.
+ *
+ * The arithmetic is done with Add, Sub, Mul, Div and Pow, so the usual rules for units apply,
+ * e.g. "20 tempC + 5 tempC" is an error.
+ */
+func Eval(expr string, vars map[string]*Qty) (*Qty, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := evalParser{tokens: tokens, vars: vars}
+	result, err := p.parseExpr(precedenceAdd)
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected %v at %v", t, t.pos)
+	}
+	return result, nil
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	pos   int
+	value float64
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+func isIdentStart(r rune) bool {
+	return unicode.IsLetter(r) || r == '_' || r == '°' || r == '%'
+}
+
+// unit exponents written as superscripts are part of the unit, e.g. m² or s⁻¹, like Parse
+func isIdentPart(r rune) bool {
+	_, superscript := superscripts[r]
+	return isIdentStart(r) || unicode.IsDigit(r) || superscript
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			// an exponent, but only if it is followed by digits, so 5 em is not 5e...
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				j := i + 1
+				if j < len(runes) && (runes[j] == '+' || runes[j] == '-') {
+					j++
+				}
+				if j < len(runes) && unicode.IsDigit(runes[j]) {
+					for j < len(runes) && unicode.IsDigit(runes[j]) {
+						j++
+					}
+					i = j
+				}
+			}
+			text := string(runes[start:i])
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at %v", text, start)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, pos: start, value: value})
+		case isIdentStart(r):
+			start := i
+			for i < len(runes) && isIdentPart(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		case r == '*' && i+1 < len(runes) && runes[i+1] == '*':
+			tokens = append(tokens, token{kind: tokenOperator, text: "^", pos: i})
+			i += 2
+		case isMultiplication(r):
+			// the multiplication signs accepted by Parse, e.g. N·m or 1.5×10³
+			tokens = append(tokens, token{kind: tokenOperator, text: "*", pos: i})
+			i++
+		case superscripts[r] != 0:
+			// an exponent after a number or a group, e.g. 10³ or (m/s)²
+			start := i
+			for i < len(runes) && superscripts[runes[i]] != 0 {
+				i++
+			}
+			text := fromSuperscript(string(runes[start:i]))
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid exponent %q at %v", string(runes[start:i]), start)
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: "^", pos: start})
+			tokens = append(tokens, token{kind: tokenNumber, text: text, pos: start, value: value})
+		case strings.ContainsRune("+-*/^", r):
+			tokens = append(tokens, token{kind: tokenOperator, text: string(r), pos: i})
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", pos: i})
+			i++
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i})
+			i++
+		default:
+			return nil, fmt.Errorf("unexpected %q at %v", r, i)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+const (
+	precedenceAdd      = 1 // + -
+	precedenceMul      = 2 // * /
+	precedenceUnary    = 3 // -
+	precedenceImplicit = 4 // a number followed by a unit, e.g. 4 s
+	precedencePow      = 5 // ^
+)
+
+var binaryPrecedence = map[string]int{
+	"+": precedenceAdd,
+	"-": precedenceAdd,
+	"*": precedenceMul,
+	"/": precedenceMul,
+	"^": precedencePow,
+}
+
+type evalParser struct {
+	tokens []token
+	pos    int
+	vars   map[string]*Qty
+}
+
+func (p *evalParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *evalParser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+// parses a sequence of operations with at least the given precedence, using precedence climbing
+func (p *evalParser) parseExpr(minPrecedence int) (*Qty, error) {
+	start := p.pos
+	lhs, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	// a number on its own, which a unit after it makes into a quantity like Parse does, e.g. 3 dBm
+	literal := p.tokens[start].kind == tokenNumber && p.pos == start+1
+	for {
+		t := p.peek()
+		if t.kind == tokenIdent && precedenceImplicit >= minPrecedence {
+			// implicit multiplication, e.g. 4 s
+			_, variable := p.vars[t.text]
+			call := p.tokens[p.pos+1].kind == tokenLeftParen
+			rhs, err := p.parseExpr(precedenceImplicit + 1)
+			if err != nil {
+				return nil, err
+			}
+			if literal && !variable && !call && rhs.scalar == 1 {
+				// levels and affine units can't be scaled, so the literal is made with New
+				lhs, err = New(lhs.scalar, rhs.Unit())
+			} else {
+				lhs, err = lhs.Mul(rhs)
+			}
+			if err != nil {
+				return nil, err
+			}
+			literal = false
+			continue
+		}
+		if t.kind != tokenOperator {
+			return lhs, nil
+		}
+		precedence := binaryPrecedence[t.text]
+		if precedence < minPrecedence {
+			return lhs, nil
+		}
+		p.next()
+		next := precedence + 1
+		if t.text == "^" {
+			// right associative, 2^3^2 is 2^(3^2)
+			next = precedence
+		}
+		rhs, err := p.parseExpr(next)
+		if err != nil {
+			return nil, err
+		}
+		if lhs, err = apply(t, lhs, rhs); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func apply(op token, lhs, rhs *Qty) (*Qty, error) {
+	switch op.text {
+	case "+":
+		return lhs.Add(rhs)
+	case "-":
+		return lhs.Sub(rhs)
+	case "*":
+		return lhs.Mul(rhs)
+	case "/":
+		return lhs.Div(rhs)
+	case "^":
+		if !rhs.IsUnitless() || rhs.scalar != math.Trunc(rhs.scalar) {
+			return nil, fmt.Errorf("exponent must be a unitless integer, got %v", rhs)
+		} else if math.Abs(rhs.scalar) > float64(MaxExponent) {
+			// checked before the conversion to int, which overflows for exponents like 1e30
+			return nil, fmt.Errorf("exponent %v is larger than %v", rhs.scalar, MaxExponent)
+		}
+		return lhs.Pow(int(rhs.scalar))
+	default:
+		return nil, fmt.Errorf("unexpected %v at %v", op, op.pos)
+	}
+}
+
+func (p *evalParser) parseUnary() (*Qty, error) {
+	t := p.peek()
+	if t.kind == tokenOperator && (t.text == "-" || t.text == "+") {
+		p.next()
+		operand, err := p.parseExpr(precedenceUnary)
+		if err != nil {
+			return nil, err
+		}
+		if t.text == "-" {
+			// negated rather than multiplied by -1, since levels can't be scaled, e.g. -3 dBm
+			return operand.withScalar(-operand.scalar)
+		}
+		return operand, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *evalParser) parsePrimary() (*Qty, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNumber:
+		return New(t.value, "")
+	case tokenLeftParen:
+		result, err := p.parseExpr(precedenceAdd)
+		if err != nil {
+			return nil, err
+		}
+		if c := p.next(); c.kind != tokenRightParen {
+			return nil, fmt.Errorf("expected \")\" at %v, got %v", c.pos, c)
+		}
+		return result, nil
+	case tokenIdent:
+		if p.peek().kind == tokenLeftParen {
+			return p.parseCall(t)
+		}
+		if v, ok := p.vars[t.text]; ok {
+			if v == nil {
+				return nil, fmt.Errorf("variable %v is nil", t.text)
+			}
+			return v, nil
+		}
+		if u, err := ParseUnit(t.text); err != nil {
+			return nil, fmt.Errorf("unknown variable or unit %q at %v", t.text, t.pos)
+		} else {
+			return New(1, u)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected %v at %v", t, t.pos)
+	}
+}
+
+func (p *evalParser) parseCall(name token) (*Qty, error) {
+	fn, ok := lookupFunction(name.text)
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at %v", name.text, name.pos)
+	}
+	p.next() // (
+	var args []*Qty
+	if p.peek().kind == tokenRightParen {
+		p.next()
+	} else {
+		for {
+			arg, err := p.parseExpr(precedenceAdd)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			t := p.next()
+			if t.kind == tokenRightParen {
+				break
+			} else if t.kind != tokenComma {
+				return nil, fmt.Errorf("expected \",\" or \")\" at %v, got %v", t.pos, t)
+			}
+		}
+	}
+	return fn(args...)
+}
@@ -0,0 +1,137 @@
+package goqty
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	a, _ := Parse("2 m")
+	b, _ := Parse("50 cm")
+	vars := map[string]*Qty{"a": a, "b": b}
+
+	tests := map[string]struct {
+		expr     string
+		expected string
+	}{
+		"number":         {"42", "42"},
+		"quantity":       {"5 m", "5 m"},
+		"unit":           {"m", "1 m"},
+		"precedence":     {"1 + 2 * 3", "7"},
+		"parentheses":    {"(1 + 2) * 3", "9"},
+		"unary minus":    {"-2^2", "-4"},
+		"double minus":   {"--3", "3"},
+		"power":          {"2^3^2", "512"},
+		"double star":    {"2**3", "8"},
+		"scientific":     {"1.5e3 m", "1500 m"},
+		"add":            {"3 m + 20 cm", "3.2 m"},
+		"sub":            {"1 m - 20 cm", "0.8 m"},
+		"calculator":     {"(3 m + 20 cm) * 2 / 4 s", "1.6 m/s"},
+		"implicit":       {"10 m / 2 s", "5 m/s"},
+		"compound":       {"5 kg*m/s^2", "5 kg*m/s^2"},
+		"implicit units": {"5 kg m/s^2", "5 kg*m/s^2"},
+		"unit power":     {"9 m^2", "9 m^2"},
+		"sqrt":           {"sqrt(9 m^2)", "3 m"},
+		"abs":            {"abs(-3 m)", "3 m"},
+		"min":            {"min(2 m, 150 cm, 3 m)", "150 cm"},
+		"max":            {"max(2 m, 150 cm, 3 m)", "3 m"},
+		"variables":      {"a + b", "2.5 m"},
+		"variable scale": {"2 a", "4 m"},
+		"variable funcs": {"max(a, b) * 2", "4 m"},
+		"temperature":    {"20 tempC + 5 degC", "25 tempC"},
+		"unicode":        {"5 µm + 1 µm", "6 µm"},
+		"levels":         {"10 dBm + 10 dB", "20 dBm"},
+		"negative level": {"-10 dBm + 10 dB", "0 dBm"},
+		"level gain":     {"20 dBm - 10 dB", "10 dBm"},
+		"sound level":    {"60 dBSPL", "60 dBSPL"},
+		"affine":         {"20 tempC - 5 tempC", "15 °C"},
+		"affine to":      {"20 tempC + 9 degF", "25 tempC"},
+		"superscript":    {"3 m² / 1.5 m", "2 m"},
+		"inverse":        {"5 s⁻¹", "5 1/s"},
+		"group power":    {"(2 m)²", "4 m^2"},
+		"middle dot":     {"2 N·m", "2 m*N"},
+		"times ten":      {"1.5×10³ Pa", "1500 Pa"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual, err := Eval(test.expr, vars); err != nil {
+				t.Errorf("failed to evaluate %v, got %v", test.expr, err)
+			} else if actual.String() != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEvalLevels(t *testing.T) {
+	a, _ := Parse("3 dBm")
+	expected, _ := a.Add(a)
+	if actual, err := Eval("3 dBm + 3 dBm", nil); err != nil {
+		t.Errorf("failed to evaluate, got %v", err)
+	} else if *actual != *expected {
+		t.Errorf("expected %v, got %v", expected, actual)
+	}
+	if q, err := Eval("2 * 3 dBm", nil); err == nil {
+		t.Errorf("expected an error scaling a level, got %v", q)
+	}
+}
+
+func TestEvalFailure(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		expected string
+	}{
+		"empty":          {"", "unexpected end of expression at 0"},
+		"incompatible":   {"1 m + 1 s", "incompatible units: m and s"},
+		"temperatures":   {"20 tempC + 5 tempC", "cannot add two temperatures"},
+		"unknown unit":   {"5 foo", "unknown variable or unit \"foo\" at 2"},
+		"unknown func":   {"foo(1)", "unknown function \"foo\" at 0"},
+		"unclosed":       {"(1 + 2", "expected \")\" at 6, got end of expression"},
+		"trailing":       {"1 + 2)", "unexpected \")\" at 5"},
+		"bad character":  {"1 $ 2", "unexpected '$' at 2"},
+		"unit exponent":  {"2^(1 m)", "exponent must be a unitless integer, got 1 m"},
+		"fractional exp": {"2^0.5", "exponent must be a unitless integer, got 0.5"},
+		"large exp":      {"1 m ^ 1e30", "exponent 1e+30 is larger than 20"},
+		"huge exp":       {"1 m ^ 3000000", "exponent 3e+06 is larger than 20"},
+		"negative exp":   {"2 ^ -21", "exponent -21 is larger than 20"},
+		"infinite exp":   {"2 ^ (1e308 * 10)", "exponent +Inf is larger than 20"},
+		"divide by zero": {"1 m / 0", "divide by zero"},
+		"arity":          {"sqrt(1, 2)", "sqrt expects 1 argument, got 2"},
+		"no args":        {"min()", "min expects at least 1 argument"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual, err := Eval(test.expr, nil); err == nil {
+				t.Errorf("expected error %v, got %v", test.expected, actual)
+			} else if err.Error() != test.expected {
+				t.Errorf("expected error %v, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+func TestRegisterFunction(t *testing.T) {
+	RegisterFunction("hypot", func(args ...*Qty) (*Qty, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("hypot expects 2 arguments, got %v", len(args))
+		}
+		x, err := args[0].Pow(2)
+		if err != nil {
+			return nil, err
+		}
+		y, err := args[1].Pow(2)
+		if err != nil {
+			return nil, err
+		}
+		if sum, err := x.Add(y); err != nil {
+			return nil, err
+		} else {
+			return sum.Sqrt()
+		}
+	})
+	if actual, err := Eval("hypot(3 m, 4 m)", nil); err != nil {
+		t.Errorf("failed to evaluate, got %v", err)
+	} else if actual.String() != "5 m" {
+		t.Errorf("expected 5 m, got %v", actual)
+	}
+}
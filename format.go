@@ -2,37 +2,61 @@ package goqty
 
 import (
 	"fmt"
+	"math"
 	"slices"
 	"strconv"
 	"strings"
-	"sync"
 )
 
-var stringifiedUnitsCache sync.Map
+// formatted units by their tokens joined with |
+var stringifiedUnitsCache = newLRUCache[string, string](1024)
 
-func (q *Qty) Units() string {
-	if q.units != "" {
-		return q.units
+func (q Qty) Units() string {
+	if q.expr == nil {
+		return ""
 	}
+	return q.expr.units
+}
 
-	numIsUnity := slices.Compare(q.numerator, unityArray) == 0
-	denIsUnity := slices.Compare(q.denominator, unityArray) == 0
-	if numIsUnity && denIsUnity {
-		q.units = ""
-		return q.units
+// returns the string representation of the units of a quantity, e.g. m/s
+func stringifyTerms(num, den []term) string {
+	if len(num) == 0 && len(den) == 0 {
+		return ""
+	} else if len(den) == 0 {
+		return stringifyProduct(num)
 	}
+	return stringifyProduct(num) + "/" + stringifyProduct(den)
+}
 
-	var numUnits = StringifyUnits(q.numerator)
-	var denUnits = StringifyUnits(q.denominator)
-	if denIsUnity {
-		q.units = numUnits
-	} else {
-		q.units = numUnits + "/" + denUnits
+// returns the string representation of a product of terms, e.g. kg*m^2; 1 if there are none
+// like simplify, terms with the same output name are combined
+func stringifyProduct(terms []term) string {
+	if len(terms) == 0 {
+		return "1"
+	}
+	var names []string
+	var powers []int
+	for _, t := range terms {
+		name := outputs[unitInfos[t.unit].name]
+		if t.prefix != noUnit {
+			name = outputs[unitInfos[t.prefix].name] + name
+		}
+		if i := slices.Index(names, name); i >= 0 {
+			powers[i] += t.power
+		} else {
+			names = append(names, name)
+			powers = append(powers, t.power)
+		}
+	}
+	for i, p := range powers {
+		if p > 1 {
+			names[i] = fmt.Sprintf("%v^%v", names[i], p)
+		}
 	}
-	return q.units
+	return strings.Join(names, "*")
 }
 
-func (q *Qty) String() string {
+func (q Qty) String() string {
 	return DefaultFormatter(q.scalar, q.Units())
 }
 
@@ -40,22 +64,66 @@ func DefaultFormatter(scalar float64, units string) string {
 	return strings.TrimSpace(fmt.Sprintf("%v %v", strconv.FormatFloat(scalar, 'f', -1, 64), units))
 }
 
-func (q *Qty) Format(fn func(scalar float64, units string) string) string {
+// Returns a formatter that writes the scalar as a mixed number rounded to the nearest 1/denominator,
+// e.g. FractionFormatter(16) formats 2.3125 in as 2 5/16 in.  Fractions are reduced, so 2.5 in is 2 1/2 in.
+func FractionFormatter(denominator int) func(scalar float64, units string) string {
+	if denominator < 1 {
+		denominator = 1
+	}
+	return func(scalar float64, units string) string {
+		sign := ""
+		if scalar < 0 {
+			sign = "-"
+			scalar = -scalar
+		}
+		whole := math.Floor(scalar)
+		num := int(math.Round((scalar - whole) * float64(denominator)))
+		den := denominator
+		if num == den {
+			whole++
+			num = 0
+		}
+		if g := gcd(num, den); g > 1 {
+			num /= g
+			den /= g
+		}
+
+		var result string
+		if num == 0 {
+			result = strconv.FormatFloat(whole, 'f', -1, 64)
+			if whole == 0 {
+				sign = ""
+			}
+		} else if whole == 0 {
+			result = fmt.Sprintf("%v/%v", num, den)
+		} else {
+			result = fmt.Sprintf("%v %v/%v", strconv.FormatFloat(whole, 'f', -1, 64), num, den)
+		}
+		return strings.TrimSpace(sign + result + " " + units)
+	}
+}
+
+// Returns a formatter that writes the scalar as a mixed number rounded to the nearest 1/2^n,
+// e.g. BinaryFractionFormatter(4) rounds to sixteenths like a tape measure.
+// n is clamped to 0..52, the bits of the fraction of a float64.
+func BinaryFractionFormatter(n int) func(scalar float64, units string) string {
+	return FractionFormatter(1 << min(max(n, 0), 52))
+}
+
+func (q Qty) Format(fn func(scalar float64, units string) string) string {
 	return fn(q.scalar, q.Units())
 }
 
 func StringifyUnits(units []string) string {
 	key := strings.Join(units, "|")
 	if cached, found := stringifiedUnitsCache.Load(key); found {
-		return cached.(string)
+		return cached
 	}
 	if isUnity := slices.Equal(units, unityArray); isUnity {
-		stringifiedUnitsCache.Store(key, "1")
-		return "1"
+		return stringifiedUnitsCache.LoadOrStore(key, "1")
 	} else {
 		result := strings.Join(simplify(getOutputNames(units)), "*")
-		stringifiedUnitsCache.Store(key, result)
-		return result
+		return stringifiedUnitsCache.LoadOrStore(key, result)
 	}
 }
 
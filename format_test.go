@@ -55,3 +55,59 @@ func TestFormatter(t *testing.T) {
 		t.Errorf("expected formatted %v, got %v", expected, f)
 	}
 }
+
+func TestFractionFormatter(t *testing.T) {
+	tests := map[string]struct {
+		expr        string
+		denominator int
+		expected    string
+	}{
+		"sixteenths":   {"2.3125 in", 16, "2 5/16 in"},
+		"reduced":      {"2.5 in", 16, "2 1/2 in"},
+		"rounded":      {"2.3 in", 16, "2 5/16 in"},
+		"rounded up":   {"2.99 in", 8, "3 in"},
+		"fraction":     {"0.375 in", 64, "3/8 in"},
+		"whole":        {"4 ft", 16, "4 ft"},
+		"negative":     {"-1.25 in", 4, "-1 1/4 in"},
+		"zero":         {"-0.01 in", 4, "0 in"},
+		"thirds":       {"1.3333 cup", 3, "1 1/3 cu"},
+		"unitless":     {"0.5", 2, "1/2"},
+		"denominator0": {"1.5 in", 0, "2 in"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q, err := Parse(test.expr)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.expr, err)
+				return
+			}
+			if actual := q.Format(FractionFormatter(test.denominator)); actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+
+}
+
+func TestBinaryFractionFormatter(t *testing.T) {
+	tests := map[string]struct {
+		n        int
+		expected string
+	}{
+		"sixteenths":  {4, "2 5/16 in"},
+		"eighths":     {3, "2 3/8 in"},
+		"whole":       {0, "2 in"},
+		"negative":    {-1, "2 in"},
+		"largest":     {52, "2 5/16 in"},
+		"too large":   {63, "2 5/16 in"},
+		"much larger": {1000, "2 5/16 in"},
+	}
+	q, _ := Parse("2.3125 in")
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if actual := q.Format(BinaryFractionFormatter(test.n)); actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package goqty
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// returned when a function that requires a unitless quantity is given one with units
+var ErrNotDimensionless = errors.New("quantity is not dimensionless")
+
+// returned when a trigonometric function is given a quantity that is not an angle
+var ErrNotAngle = errors.New("quantity is not an angle")
+
+// Returns the sine of an angle, e.g. 30 deg => 0.5
+func (q Qty) Sin() (*Qty, error) {
+	return q.angleFunc(math.Sin)
+}
+
+// Returns the cosine of an angle, e.g. 60 deg => 0.5
+func (q Qty) Cos() (*Qty, error) {
+	return q.angleFunc(math.Cos)
+}
+
+// Returns the tangent of an angle, e.g. 45 deg => 1
+func (q Qty) Tan() (*Qty, error) {
+	return q.angleFunc(math.Tan)
+}
+
+// Returns the arcsine of a unitless quantity in radians, e.g. 1 => 1.5707963267948966 rad
+func (q Qty) Asin() (*Qty, error) {
+	return q.inverseAngleFunc("asin", math.Asin)
+}
+
+// Returns the arccosine of a unitless quantity in radians, e.g. 0 => 1.5707963267948966 rad
+func (q Qty) Acos() (*Qty, error) {
+	return q.inverseAngleFunc("acos", math.Acos)
+}
+
+// Returns the arctangent of a unitless quantity in radians, e.g. 1 => 0.7853981633974483 rad
+func (q Qty) Atan() (*Qty, error) {
+	return q.inverseAngleFunc("atan", math.Atan)
+}
+
+// Returns the arctangent of q/x in radians, using the signs of both to determine the quadrant.
+// Both quantities must be compatible, e.g. Atan2 of 3 m and -300 cm => 2.356194490192345 rad
+func (q Qty) Atan2(x interface{}) (*Qty, error) {
+	var o *Qty
+	var err error
+	switch t := x.(type) {
+	case *Qty:
+		o = x.(*Qty)
+	case Qty:
+		o = &t
+	case string:
+		if o, err = Parse(x.(string)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("expecting string or *Qty, got %T", t)
+	}
+
+	if !q.IsCompatible(o) {
+		return nil, fmt.Errorf("incompatible units: %v and %v", q.Units(), o.Units())
+	}
+	return newQty(math.Atan2(q.baseScalar, o.baseScalar), []string{"<radian>"}, unityArray)
+}
+
+// Returns e raised to the power of a unitless quantity
+func (q Qty) Exp() (*Qty, error) {
+	return q.dimensionlessFunc("exp", math.Exp)
+}
+
+// Returns the natural logarithm of a unitless quantity
+func (q Qty) Log() (*Qty, error) {
+	return q.dimensionlessFunc("log", math.Log)
+}
+
+// Returns the decimal logarithm of a unitless quantity
+func (q Qty) Log10() (*Qty, error) {
+	return q.dimensionlessFunc("log10", math.Log10)
+}
+
+// applies fn to an angle in radians, unitless quantities are taken to be in radians
+func (q Qty) angleFunc(fn func(float64) float64) (*Qty, error) {
+	if kind := q.Kind(); kind != "angle" && kind != "unitless" {
+		return nil, fmt.Errorf("%w: %v", ErrNotAngle, q.Units())
+	}
+	return newQty(fn(q.baseScalar), unityArray, unityArray)
+}
+
+// applies fn to a unitless quantity and returns the result in radians
+func (q Qty) inverseAngleFunc(name string, fn func(float64) float64) (*Qty, error) {
+	if q.Kind() != "unitless" {
+		return nil, fmt.Errorf("%w: %v", ErrNotDimensionless, q.Units())
+	}
+	if r := fn(q.baseScalar); math.IsNaN(r) {
+		return nil, fmt.Errorf("%v is outside the domain of %v", q, name)
+	} else {
+		return newQty(r, []string{"<radian>"}, unityArray)
+	}
+}
+
+// applies fn to a unitless quantity and returns a unitless result
+func (q Qty) dimensionlessFunc(name string, fn func(float64) float64) (*Qty, error) {
+	if q.Kind() != "unitless" {
+		return nil, fmt.Errorf("%w: %v", ErrNotDimensionless, q.Units())
+	}
+	if r := fn(q.baseScalar); math.IsNaN(r) || math.IsInf(r, -1) {
+		return nil, fmt.Errorf("%v is outside the domain of %v", q, name)
+	} else {
+		return newQty(r, unityArray, unityArray)
+	}
+}
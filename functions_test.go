@@ -0,0 +1,154 @@
+package goqty
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestTrigonometric(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		fn       func(*Qty) (*Qty, error)
+		expected float64
+		units    string
+	}{
+		"sin 30 deg":         {"30 deg", (*Qty).Sin, 0.5, ""},
+		"sin pi/2 rad":       {"1.5707963267948966 rad", (*Qty).Sin, 1, ""},
+		"sin 100 gon":        {"100 gon", (*Qty).Sin, 1, ""},
+		"cos 60 deg":         {"60 deg", (*Qty).Cos, 0.5, ""},
+		"cos 5400 arcmin":    {"5400 arcmin", (*Qty).Cos, 0, ""},
+		"tan 45 deg":         {"45 deg", (*Qty).Tan, 1, ""},
+		"tan 0.5 (unitless)": {"0.5", (*Qty).Tan, math.Tan(0.5), ""},
+		"asin 1":             {"1", (*Qty).Asin, math.Pi / 2, "rad"},
+		"acos 0":             {"0", (*Qty).Acos, math.Pi / 2, "rad"},
+		"atan 1":             {"1", (*Qty).Atan, math.Pi / 4, "rad"},
+		"asin 50%":           {"50%", (*Qty).Asin, math.Pi / 6, "rad"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q, err := Parse(test.expr)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.expr, err)
+				return
+			}
+			if actual, err := test.fn(q); err != nil {
+				t.Errorf("unexpected error %v", err)
+			} else {
+				if math.Abs(actual.scalar-test.expected) > 1e-9 {
+					t.Errorf("expected scalar %v, got %v", test.expected, actual.scalar)
+				}
+				if actual.Units() != test.units {
+					t.Errorf("expected units %v, got %v", test.units, actual.Units())
+				}
+			}
+		})
+	}
+}
+
+func TestAtan2(t *testing.T) {
+	tests := map[string]struct {
+		y        string
+		x        string
+		expected float64
+		err      string
+	}{
+		"3 m, 3 m":     {"3 m", "3 m", math.Pi / 4, ""},
+		"3 m, -300 cm": {"3 m", "-300 cm", 3 * math.Pi / 4, ""},
+		"-1 ft, 0 in":  {"-1 ft", "0 in", -math.Pi / 2, ""},
+		"1, 1":         {"1", "1", math.Pi / 4, ""},
+		"3 m, 3 s":     {"3 m", "3 s", 0, "incompatible units: m and s"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			y, err := Parse(test.y)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.y, err)
+				return
+			}
+			if actual, err := y.Atan2(test.x); err != nil {
+				if err.Error() != test.err {
+					t.Errorf("expected %v, got %v", test.err, err)
+				}
+			} else {
+				if math.Abs(actual.scalar-test.expected) > 1e-9 {
+					t.Errorf("expected scalar %v, got %v", test.expected, actual.scalar)
+				}
+				if actual.Kind() != "angle" {
+					t.Errorf("expected kind angle, got %v", actual.Kind())
+				}
+			}
+		})
+	}
+}
+
+func TestTranscendental(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		fn       func(*Qty) (*Qty, error)
+		expected float64
+	}{
+		"exp 0":     {"0", (*Qty).Exp, 1},
+		"exp 1":     {"1", (*Qty).Exp, math.E},
+		"log e":     {"2.718281828459045", (*Qty).Log, 1},
+		"log10 100": {"100", (*Qty).Log10, 2},
+		"log10 10%": {"10%", (*Qty).Log10, -1},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q, err := Parse(test.expr)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.expr, err)
+				return
+			}
+			if actual, err := test.fn(q); err != nil {
+				t.Errorf("unexpected error %v", err)
+			} else {
+				if math.Abs(actual.scalar-test.expected) > 1e-9 {
+					t.Errorf("expected scalar %v, got %v", test.expected, actual.scalar)
+				}
+				if !actual.IsUnitless() {
+					t.Errorf("expected unitless result, got %v", actual.Units())
+				}
+			}
+		})
+	}
+}
+
+func TestFunctionFailure(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		fn       func(*Qty) (*Qty, error)
+		sentinel error
+		expected string
+	}{
+		"sin 1 m":    {"1 m", (*Qty).Sin, ErrNotAngle, "quantity is not an angle: m"},
+		"cos 1 s":    {"1 s", (*Qty).Cos, ErrNotAngle, "quantity is not an angle: s"},
+		"asin 1 m":   {"1 m", (*Qty).Asin, ErrNotDimensionless, "quantity is not dimensionless: m"},
+		"asin 2":     {"2", (*Qty).Asin, nil, "2 is outside the domain of asin"},
+		"atan 1 rad": {"1 rad", (*Qty).Atan, ErrNotDimensionless, "quantity is not dimensionless: rad"},
+		"exp 1 kg":   {"1 kg", (*Qty).Exp, ErrNotDimensionless, "quantity is not dimensionless: kg"},
+		"log 2 m":    {"2 m", (*Qty).Log, ErrNotDimensionless, "quantity is not dimensionless: m"},
+		"log 0":      {"0", (*Qty).Log, nil, "0 is outside the domain of log"},
+		"log10 -1":   {"-1", (*Qty).Log10, nil, "-1 is outside the domain of log10"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q, err := Parse(test.expr)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.expr, err)
+				return
+			}
+			if actual, err := test.fn(q); err != nil {
+				if err.Error() != test.expected {
+					t.Errorf("expected %v, got %v", test.expected, err)
+				}
+				if test.sentinel != nil && !errors.Is(err, test.sentinel) {
+					t.Errorf("expected error to wrap %v", test.sentinel)
+				}
+			} else {
+				t.Errorf("expected error %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+//go:build ignore
+
+// Generates units_gen.go, a variable for every unit and a function for every prefix in definitions.go,
+// e.g. Meter and Kilo(Gram).
+//
+// Run with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// names that can't be derived from the unit name because they would clash
+var identifiers = map[string]string{
+	"<unit>":    "EnzymeUnit",
+	"<Calorie>": "FoodCalorie",
+	"<Bps>":     "BytesPerSecond",
+	"<bps>":     "BitsPerSecond",
+	"<Ah>":      "AmpereHour",
+	"<Wh>":      "WattHour",
+}
+
+type definition struct {
+	name    string
+	kind    string
+	aliases []string
+}
+
+func main() {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "definitions.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	prefixes := definitions(file, "prefixes")
+	units := definitions(file, "units")
+
+	var buf bytes.Buffer
+	fmt.Fprintln(&buf, "// Code generated by go run gen_units.go; DO NOT EDIT.")
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "package goqty")
+	fmt.Fprintln(&buf)
+
+	fmt.Fprintln(&buf, "var (")
+	for _, u := range units {
+		fmt.Fprintf(&buf, "\t// %v is the %v unit %v, also %v\n", identifier(u.name), u.kind, u.name, strings.Join(u.aliases, ", "))
+		fmt.Fprintf(&buf, "\t%v = mustUnit(%q)\n", identifier(u.name), u.name)
+	}
+	fmt.Fprintln(&buf, ")")
+
+	for _, p := range prefixes {
+		fmt.Fprintln(&buf)
+		fmt.Fprintf(&buf, "// %v applies the prefix %v, also %v, to a unit\n", identifier(p.name), p.name, strings.Join(p.aliases, ", "))
+		fmt.Fprintf(&buf, "func %v(u Unit) Unit {\n", identifier(p.name))
+		fmt.Fprintf(&buf, "\treturn mustPrefix(%q, u)\n", p.name)
+		fmt.Fprintln(&buf, "}")
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("units_gen.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// returns the definitions made with makeUnit in the map literal assigned to the named variable, sorted by identifier
+func definitions(file *ast.File, variable string) []definition {
+	var result []definition
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Names[0].Name != variable {
+			return true
+		}
+		for _, elt := range spec.Values[0].(*ast.CompositeLit).Elts {
+			kv := elt.(*ast.KeyValueExpr)
+			call, ok := kv.Value.(*ast.CallExpr)
+			if !ok {
+				// e.g. unityUnit
+				continue
+			}
+			d := definition{name: unquote(kv.Key), kind: unquote(call.Args[0])}
+			for _, alias := range call.Args[1].(*ast.CompositeLit).Elts {
+				d.aliases = append(d.aliases, unquote(alias))
+			}
+			result = append(result, d)
+		}
+		return false
+	})
+	sort.Slice(result, func(i, j int) bool {
+		return identifier(result[i].name) < identifier(result[j].name)
+	})
+	return result
+}
+
+func unquote(expr ast.Expr) string {
+	s, err := strconv.Unquote(expr.(*ast.BasicLit).Value)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return s
+}
+
+// returns the exported Go identifier for a unit name, e.g. <pound-force> => PoundForce
+func identifier(name string) string {
+	if id, ok := identifiers[name]; ok {
+		return id
+	}
+	var result strings.Builder
+	for _, part := range strings.Split(strings.Trim(name, "<>"), "-") {
+		result.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return result.String()
+}
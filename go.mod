@@ -1,5 +1,5 @@
 module github.com/wjanssens/goqty
 
-go 1.23.2
+go 1.24.0
 
 require golang.org/x/text v0.19.0
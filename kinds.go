@@ -1,68 +1,323 @@
 package goqty
 
-var kinds = map[int]string{
-	-312078:      "elastance",
-	-312058:      "resistance",
-	-312057:      "resistivity",
-	-312038:      "inductance",
-	-152058:      "potential",
-	-152040:      "magnetism",
-	-152038:      "magnetism",
-	-7997:        "specific_volume",
-	-79:          "snap",
-	-59:          "jolt",
-	-39:          "acceleration",
-	-38:          "radiation",
-	-20:          "frequency",
-	-19:          "speed",
-	-18:          "viscosity",
-	-17:          "volumetric_flow",
-	-1:           "wavenumber",
-	0:            "unitless",
-	1:            "length",
-	2:            "area",
-	3:            "volume",
-	20:           "time",
-	400:          "temperature",
-	7941:         "yank",
-	7942:         "power",
-	7959:         "pressure",
-	7961:         "force",
-	7962:         "energy",
-	7979:         "viscosity",
-	7981:         "momentum",
-	7982:         "angular_momentum",
-	7997:         "density",
-	7998:         "area_density",
-	8000:         "mass",
-	152020:       "radiation_exposure",
-	159999:       "magnetism",
-	160000:       "current",
-	160020:       "charge",
-	312057:       "conductivity",
-	312058:       "conductance",
-	312078:       "capacitance",
-	3199980:      "activity",
-	3199997:      "molar_concentration",
-	3200000:      "substance",
-	63999998:     "illuminance",
-	64000000:     "luminous_power",
-	1280000000:   "currency",
-	25599999980:  "information_rate",
-	25600000000:  "information",
-	511999999980: "angular_velocity",
-	512000000000: "angle",
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// the well-known kinds of units by their dimension
+var kinds = map[Dimension]string{
+	{Length: 2, Mass: 1, Time: -4, Current: -2}: "elastance",
+	{Length: 2, Mass: 1, Time: -3, Current: -2}: "resistance",
+	{Length: 3, Mass: 1, Time: -3, Current: -2}: "resistivity",
+	{Length: 2, Mass: 1, Time: -2, Current: -2}: "inductance",
+	{Length: 2, Mass: 1, Time: -3, Current: -1}: "potential",
+	{Mass: 1, Time: -2, Current: -1}:            "magnetism",
+	{Length: 2, Mass: 1, Time: -2, Current: -1}: "magnetism",
+	{Length: 3, Mass: -1}:                       "specific_volume",
+	{Length: 1, Time: -4}:                       "snap",
+	{Length: 1, Time: -3}:                       "jolt",
+	{Length: 1, Time: -2}:                       "acceleration",
+	{Length: 2, Time: -2}:                       "radiation",
+	{Time: -1}:                                  "frequency",
+	{Length: 1, Time: -1}:                       "speed",
+	{Length: 2, Time: -1}:                       "viscosity",
+	{Length: 3, Time: -1}:                       "volumetric_flow",
+	{Length: -1}:                                "wavenumber",
+	{}:                                          "unitless",
+	{Length: 1}:                                 "length",
+	{Length: 2}:                                 "area",
+	{Length: 3}:                                 "volume",
+	{Time: 1}:                                   "time",
+	{Temperature: 1}:                            "temperature",
+	{Length: 1, Mass: 1, Time: -3}:              "yank",
+	{Length: 2, Mass: 1, Time: -3}:              "power",
+	{Length: -1, Mass: 1, Time: -2}:             "pressure",
+	{Length: 1, Mass: 1, Time: -2}:              "force",
+	{Length: 2, Mass: 1, Time: -2}:              "energy",
+	{Length: -1, Mass: 1, Time: -1}:             "viscosity",
+	{Length: 1, Mass: 1, Time: -1}:              "momentum",
+	{Length: 2, Mass: 1, Time: -1}:              "angular_momentum",
+	{Length: -3, Mass: 1}:                       "density",
+	{Length: -2, Mass: 1}:                       "area_density",
+	{Mass: 1}:                                   "mass",
+	{Mass: -1, Time: 1, Current: 1}:             "radiation_exposure",
+	{Length: -1, Current: 1}:                    "magnetism",
+	{Current: 1}:                                "current",
+	{Time: 1, Current: 1}:                       "charge",
+	{Length: -3, Mass: -1, Time: 3, Current: 2}: "conductivity",
+	{Length: -2, Mass: -1, Time: 3, Current: 2}: "conductance",
+	{Length: -2, Mass: -1, Time: 4, Current: 2}: "capacitance",
+	{Time: -1, Substance: 1}:                    "activity",
+	{Length: -3, Substance: 1}:                  "molar_concentration",
+	{Substance: 1}:                              "substance",
+	{Luminosity: 1}:                             "luminosity",
+	{Length: -2, Luminosity: 1, SolidAngle: 1}:  "illuminance",
+	{Luminosity: 1, SolidAngle: 1}:              "luminous_power",
+	{Currency: 1}:                               "currency",
+	{Time: -1, Information: 1}:                  "information_rate",
+	{Information: 1}:                            "information",
+	{Time: -1, Angle: 1}:                        "angular_velocity",
+	{Angle: 1}:                                  "angle",
+	{SolidAngle: 1}:                             "solid_angle",
+	{Count: 1}:                                  "counting",
 }
 
-// Returns the list of available well-known kinds of units, e.g.
+var kindsMu sync.RWMutex
+
+// Returns the sorted list of available well-known kinds of units, e.g.
 // "radiation" or "length".
 func Kinds() []string {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
 	var result []string
 	for _, k := range kinds {
 		result = append(result, k)
 	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// Returns the kind of the quantity, described like KindOf for a dimension without a well-known kind
+func (q Qty) Kind() string {
+	kindsMu.RLock()
+	defer kindsMu.RUnlock()
+	if k, ok := kinds[q.Dimension()]; ok {
+		return k
+	}
+
+	num, numDerived := termsKind(q.numTerms())
+	den, denDerived := termsKind(q.denTerms())
+	if !numDerived && !denDerived {
+		return q.DimensionFormula()
+	} else if num == "" {
+		num = "1"
+	}
+	if den == "" {
+		return num
+	}
+	return num + "/" + den
+}
+
+// Registers a name for the kind of units, e.g. RegisterKind("mass_flow", "kg/s").
+// The name is returned by Kind and KindOf for all units of the same dimension.
+func RegisterKind(name string, units string) error {
+	if name == "" {
+		return fmt.Errorf("kind name is empty")
+	}
+	u, err := ParseUnit(units)
+	if err != nil {
+		return err
+	}
+	kindsMu.Lock()
+	defer kindsMu.Unlock()
+	if k, ok := kinds[u.Dimension()]; ok {
+		return fmt.Errorf("%v is already of kind %v", units, k)
+	}
+	kinds[u.Dimension()] = name
+	return nil
+}
+
+/* Returns the kind of units, e.g. KindOf("kg*m/s^2") is force.
+ * Units of a dimension without a well-known kind are described by the kinds of their numerator and denominator,
+ * e.g. W/(m^2*K) is power/area·temperature, or by their dimension formula if those are all base quantities,
+ * e.g. kg/s is mass·time⁻¹.
+ */
+func KindOf(units string) (string, error) {
+	u, err := ParseUnit(units)
+	if err != nil {
+		return "", err
+	}
+	return u.Kind(), nil
+}
+
+// describes the kind of terms, as a single kind if they have one, and otherwise as the kinds of each term joined by ·
+// derived is true if any of the kinds is well-known and not a base quantity, e.g. power but not mass
+// the kinds lock must be held
+func termsKind(terms []term) (kind string, derived bool) {
+	var dims []Dimension
+	var sum Dimension
+	for _, t := range terms {
+		if d := (Dimension{}).add(unitInfos[t.unit].dimension, t.power); d != (Dimension{}) {
+			dims = append(dims, d)
+			sum = sum.add(d, 1)
+		}
+	}
+	if _, ok := kinds[sum]; ok && len(dims) > 1 {
+		dims = []Dimension{sum}
+	}
+	var names []string
+	for _, d := range dims {
+		if k, ok := kinds[d]; !ok {
+			names = append(names, d.Formula())
+		} else {
+			names = append(names, k)
+			derived = derived || baseDimensions[k] != d
+		}
+	}
+	return strings.Join(names, "·"), derived
+}
+
+// KindDetails describes a kind of units, see KindInfo
+type KindDetails struct {
+	Name        string
+	Description string // empty for kinds added with RegisterKind
+	// the dimensions of the kind, usually one, but e.g. viscosity is both dynamic (Pa*s) and kinematic (m^2/s)
+	Dimensions []Dimension
+	// the SI coherent units of the dimensions, e.g. N for force or m/s for speed;
+	// a dimension whose base quantities have no defined units, e.g. currency, has none
+	SIUnits []Unit
+	Units   []UnitDetails // the defined units of the dimensions, sorted by name
+}
+
+// UnitDetails describes a defined unit, see KindInfo
+type UnitDetails struct {
+	Name     string // e.g. <meter>
+	Symbol   string // e.g. m
+	LongName string // e.g. meter
+	Aliases  []string
+}
+
+var kindDescriptions = map[string]string{
+	"acceleration":        "rate of change of speed",
+	"activity":            "catalytic activity, the rate of a chemical reaction",
+	"angle":               "plane angle",
+	"angular_momentum":    "rotational momentum",
+	"angular_velocity":    "rate of change of angle",
+	"area":                "extent of a surface",
+	"area_density":        "mass per unit of area",
+	"capacitance":         "ability to store electric charge",
+	"charge":              "electric charge",
+	"conductance":         "ease with which an electric current flows",
+	"conductivity":        "electrical conductivity of a material",
+	"counting":            "number of discrete things",
+	"currency":            "amount of money",
+	"current":             "electric current",
+	"density":             "mass per unit of volume",
+	"elastance":           "reciprocal of capacitance",
+	"energy":              "work or heat",
+	"force":               "push or pull on a mass",
+	"frequency":           "number of occurrences per unit of time",
+	"illuminance":         "luminous flux per unit of area",
+	"inductance":          "opposition to a change in electric current",
+	"information":         "amount of data",
+	"information_rate":    "amount of data per unit of time",
+	"jolt":                "rate of change of acceleration",
+	"length":              "distance",
+	"luminosity":          "luminous intensity",
+	"luminous_power":      "luminous flux, the perceived power of light",
+	"magnetism":           "magnetic flux, flux density and field strength",
+	"mass":                "amount of matter",
+	"molar_concentration": "amount of substance per unit of volume",
+	"momentum":            "mass in motion",
+	"potential":           "electric potential difference, or voltage",
+	"power":               "rate of transfer of energy",
+	"pressure":            "force per unit of area",
+	"radiation":           "absorbed or equivalent dose of ionizing radiation",
+	"radiation_exposure":  "exposure to ionizing radiation",
+	"resistance":          "opposition to an electric current",
+	"resistivity":         "electrical resistivity of a material",
+	"snap":                "rate of change of jolt",
+	"solid_angle":         "two-dimensional angle in three-dimensional space",
+	"specific_volume":     "volume per unit of mass",
+	"speed":               "rate of change of position",
+	"substance":           "amount of substance",
+	"temperature":         "thermodynamic temperature",
+	"time":                "duration",
+	"unitless":            "dimensionless ratio",
+	"viscosity":           "resistance to flow",
+	"volume":              "extent of a solid",
+	"volumetric_flow":     "volume per unit of time",
+	"wavenumber":          "number of waves per unit of length",
+	"yank":                "rate of change of force",
+}
+
+// the units with special names in the SI; the first of a dimension is its coherent unit, e.g. Hz rather than Bq
+var siUnitNames = []string{"<radian>", "<steradian>", "<hertz>", "<newton>", "<pascal>", "<joule>", "<watt>", "<coulomb>", "<volt>",
+	"<farad>", "<ohm>", "<siemens>", "<weber>", "<tesla>", "<henry>", "<lumen>", "<lux>", "<gray>", "<katal>"}
+
+// the coherent units of the base quantities, in the order of the fields of Dimension
+var siBaseUnitNames = []string{"<meter>", "<kilogram>", "<second>", "<ampere>", "<kelvin>", "<mole>",
+	"<candela>", "<dollar>", "<byte>", "<radian>", "<steradian>", "<each>"}
+
+// Returns the dimensions, SI unit, units and description of a kind returned by Kinds, e.g. KindInfo("force")
+func KindInfo(name string) (KindDetails, error) {
+	result := KindDetails{Name: name, Description: kindDescriptions[name], Dimensions: kindDimensions(name)}
+	if len(result.Dimensions) == 0 {
+		return KindDetails{}, fmt.Errorf("unknown kind %v", name)
+	}
+
+	for _, d := range result.Dimensions {
+		if u, ok := coherentUnit(d); ok {
+			result.SIUnits = append(result.SIUnits, u)
+		}
+	}
+	for name, u := range units {
+		if name != unity && slices.Contains(result.Dimensions, unitInfos[unitIDs[name]].dimension) {
+			result.Units = append(result.Units, UnitDetails{name, u.aliases[0], longName(name), u.aliases})
+		}
+	}
+	slices.SortFunc(result.Units, func(a, b UnitDetails) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result, nil
+}
+
+// returns the long name of a unit, e.g. meter for <meter> or degree Celsius for <temp-C>
+func longName(name string) string {
+	if n, ok := longNames[name]; ok {
+		return n
+	}
+	return strings.Trim(name, "<>")
+}
+
+// returns the dimensions of a kind sorted by formula
+func kindDimensions(name string) []Dimension {
+	var result []Dimension
+	kindsMu.RLock()
+	for d, k := range kinds {
+		if k == name {
+			result = append(result, d)
+		}
+	}
+	kindsMu.RUnlock()
+	slices.SortFunc(result, func(a, b Dimension) int {
+		return strings.Compare(a.Formula(), b.Formula())
+	})
 	return result
 }
-func (q *Qty) Kind() string {
-	return kinds[q.signature]
+
+// returns the SI coherent unit of a kind, e.g. m for length,
+// or of the first of its dimensions sorted by formula, e.g. Pa*s for viscosity
+func kindUnit(name string) (Unit, bool) {
+	if dims := kindDimensions(name); len(dims) > 0 {
+		return coherentUnit(dims[0])
+	}
+	return Unit{}, false
+}
+
+// returns the SI coherent unit of a dimension, a unit with a special name if there is one, e.g. N,
+// and otherwise a product of base units, e.g. m/s
+// returns false if a base unit isn't defined
+func coherentUnit(d Dimension) (Unit, bool) {
+	for _, name := range siUnitNames {
+		if id, ok := unitIDs[name]; ok && unitInfos[id].dimension == d {
+			return mustUnit(name), true
+		}
+	}
+	var num, den []term
+	for i, e := range d.exponents() {
+		id, ok := unitIDs[siBaseUnitNames[i]]
+		if *e != 0 && !ok {
+			return Unit{}, false
+		} else if *e > 0 {
+			num = append(num, term{noUnit, id, *e})
+		} else if *e < 0 {
+			den = append(den, term{noUnit, id, -*e})
+		}
+	}
+	u, err := newUnit(num, den)
+	return u, err == nil
 }
@@ -0,0 +1,185 @@
+package goqty
+
+import (
+	"cmp"
+	"slices"
+	"testing"
+)
+
+func TestKindOf(t *testing.T) {
+	tests := map[string]struct {
+		units    string
+		expected string
+		formula  string
+	}{
+		"unitless":         {"", "unitless", "1"},
+		"registered":       {"kg*m/s^2", "force", "length·mass·time⁻²"},
+		"base quantities":  {"kg/s", "mass·time⁻¹", "mass·time⁻¹"},
+		"power":            {"m^4", "length⁴", "length⁴"},
+		"inverse":          {"1/s^3", "time⁻³", "time⁻³"},
+		"derived":          {"W/(m^2*degK)", "power/temperature·area", "mass·time⁻³·temperature⁻¹"},
+		"numerator kinds":  {"N*s^2", "force·time²", "length·mass"},
+		"denominator kind": {"J/degK", "energy/temperature", "length²·mass·time⁻²·temperature⁻¹"},
+		"reciprocal":       {"1/(W*kg)", "1/mass·power", "length⁻²·mass⁻²·time³"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			kind, err := KindOf(test.units)
+			if err != nil {
+				t.Fatalf("failed to resolve kind, got %v", err)
+			}
+			if kind != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, kind)
+			}
+			if u, _ := ParseUnit(test.units); u.DimensionFormula() != test.formula {
+				t.Errorf("expected formula %v, got %v", test.formula, u.DimensionFormula())
+			}
+			if q, _ := Parse("1 " + test.units); q.Kind() != test.expected {
+				t.Errorf("expected kind %v, got %v", test.expected, q.Kind())
+			}
+		})
+	}
+
+	if _, err := KindOf("foo"); err == nil {
+		t.Errorf("expected error for unknown units")
+	}
+}
+
+func TestRegisterKind(t *testing.T) {
+	defer func() {
+		kindsMu.Lock()
+		delete(kinds, Dimension{Mass: 1, Time: -1})
+		delete(kinds, Dimension{Mass: 1, Time: -3, Temperature: -1})
+		kindsMu.Unlock()
+	}()
+
+	if err := RegisterKind("mass_flow", "kg/s"); err != nil {
+		t.Fatalf("failed to register, got %v", err)
+	}
+	if err := RegisterKind("heat_transfer_coefficient", "W/(m^2*degK)"); err != nil {
+		t.Fatalf("failed to register, got %v", err)
+	}
+	if kind, _ := KindOf("lb/h"); kind != "mass_flow" {
+		t.Errorf("expected mass_flow, got %v", kind)
+	}
+	if kind, _ := KindOf("BTU/(h*ft^2*degF)"); kind != "heat_transfer_coefficient" {
+		t.Errorf("expected heat_transfer_coefficient, got %v", kind)
+	}
+	if q, _ := Parse("3 t/h"); q.Kind() != "mass_flow" {
+		t.Errorf("expected mass_flow, got %v", q.Kind())
+	}
+	if !slices.Contains(Kinds(), "mass_flow") {
+		t.Errorf("expected mass_flow in %v", Kinds())
+	}
+	if info, err := KindInfo("mass_flow"); err != nil {
+		t.Errorf("failed to get kind, got %v", err)
+	} else if len(info.SIUnits) != 1 || info.SIUnits[0].String() != "kg/s" {
+		t.Errorf("expected kg/s, got %v", info.SIUnits)
+	}
+
+	failures := map[string]struct {
+		name  string
+		units string
+		err   string
+	}{
+		"empty name": {"", "kg/min", "kind name is empty"},
+		"registered": {"flow", "g/s", "g/s is already of kind mass_flow"},
+		"well-known": {"push", "N", "N is already of kind force"},
+		"unknown":    {"foo", "foo", "unit not recognized"},
+	}
+	for name, test := range failures {
+		t.Run(name, func(t *testing.T) {
+			if err := RegisterKind(test.name, test.units); err == nil || err.Error() != test.err {
+				t.Errorf("expected error %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestKinds(t *testing.T) {
+	kinds := Kinds()
+	if !slices.IsSorted(kinds) {
+		t.Errorf("expected sorted kinds, got %v", kinds)
+	}
+	if len(slices.Compact(slices.Clone(kinds))) != len(kinds) {
+		t.Errorf("expected distinct kinds, got %v", kinds)
+	}
+	for _, kind := range kinds {
+		if info, err := KindInfo(kind); err != nil {
+			t.Errorf("failed to get %v, got %v", kind, err)
+		} else if info.Description == "" {
+			t.Errorf("expected a description of %v", kind)
+		}
+	}
+}
+
+func TestKindInfo(t *testing.T) {
+	tests := map[string]struct {
+		dimensions []Dimension
+		siUnits    []string
+		unit       UnitDetails // one of the units
+	}{
+		"force":     {[]Dimension{{Length: 1, Mass: 1, Time: -2}}, []string{"N"}, UnitDetails{"<newton>", "N", "newton", []string{"N", "Newton", "newton"}}},
+		"speed":     {[]Dimension{{Length: 1, Time: -1}}, []string{"m/s"}, UnitDetails{"<knot>", "kt", "knot", []string{"kt", "kn", "kts", "knot", "knots"}}},
+		"frequency": {[]Dimension{{Time: -1}}, []string{"Hz"}, UnitDetails{"<becquerel>", "Bq", "becquerel", []string{"Bq", "becquerel", "becquerels"}}},
+		"viscosity": {[]Dimension{{Length: 2, Time: -1}, {Length: -1, Mass: 1, Time: -1}}, []string{"m^2/s", "kg/m*s"}, UnitDetails{"<poise>", "P", "poise", []string{"P", "poise"}}},
+		"power":     {[]Dimension{{Length: 2, Mass: 1, Time: -3}}, []string{"W"}, UnitDetails{"<decibel-milliwatt>", "dBm", "decibel relative to one milliwatt", []string{"dBm", "dBmW"}}},
+		"currency":  {[]Dimension{{Currency: 1}}, nil, UnitDetails{}},
+		"pressure":  {[]Dimension{{Length: -1, Mass: 1, Time: -2}}, []string{"Pa"}, UnitDetails{"<cmh2o>", "cmH2O", "centimeter of water", []string{"cmH2O", "cmh2o"}}},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			info, err := KindInfo(name)
+			if err != nil {
+				t.Fatalf("failed to get kind, got %v", err)
+			}
+			if info.Name != name || info.Description == "" {
+				t.Errorf("expected the name and description of %v, got %v %v", name, info.Name, info.Description)
+			}
+			if !slices.Equal(info.Dimensions, test.dimensions) {
+				t.Errorf("expected %v, got %v", test.dimensions, info.Dimensions)
+			}
+			var siUnits []string
+			for _, u := range info.SIUnits {
+				siUnits = append(siUnits, u.String())
+			}
+			if !slices.Equal(siUnits, test.siUnits) {
+				t.Errorf("expected SI units %v, got %v", test.siUnits, siUnits)
+			}
+			// the zero value of a Quantity uses the first SI unit, which must not depend on map order
+			for range 10 {
+				if u, ok := kindUnit(name); ok != (len(siUnits) > 0) || ok && u.String() != siUnits[0] {
+					t.Errorf("expected unit of kind %v, got %v %v", siUnits, u, ok)
+				}
+			}
+			if !slices.IsSortedFunc(info.Units, func(a, b UnitDetails) int { return cmp.Compare(a.Name, b.Name) }) {
+				t.Errorf("expected sorted units, got %v", info.Units)
+			}
+			if test.unit.Name == "" {
+				if len(info.Units) != 0 {
+					t.Errorf("expected no units, got %v", info.Units)
+				}
+			} else if i := slices.IndexFunc(info.Units, func(u UnitDetails) bool { return u.Name == test.unit.Name }); i < 0 {
+				t.Errorf("expected %v in %v", test.unit.Name, info.Units)
+			} else if u := info.Units[i]; u.Symbol != test.unit.Symbol || u.LongName != test.unit.LongName || !slices.Equal(u.Aliases, test.unit.Aliases) {
+				t.Errorf("expected %v, got %v", test.unit, u)
+			}
+		})
+	}
+
+	if _, err := KindInfo("foo"); err == nil || err.Error() != "unknown kind foo" {
+		t.Errorf("expected error unknown kind foo, got %v", err)
+	}
+}
+
+// every unit without an entry in longNames must be named after one of its aliases, e.g. <meter> and meter
+func TestLongNames(t *testing.T) {
+	for name, u := range units {
+		if _, ok := longNames[name]; !ok && name != unity && !slices.Contains(u.aliases, longName(name)) {
+			t.Errorf("expected a long name for %v", name)
+		}
+	}
+	if n := longName("<temp-C>"); n != "degree Celsius" {
+		t.Errorf("expected degree Celsius, got %v", n)
+	}
+}
@@ -0,0 +1,148 @@
+package goqty
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Returns true if the quantity is a logarithmic level relative to a reference value, e.g. dBm or dBV
+func (q Qty) IsLevel() bool {
+	return q.expr != nil && q.expr.level
+}
+
+func isLevel(num, den []term) bool {
+	return len(num) == 1 && len(den) == 0 && num[0].prefix == noUnit && num[0].power == 1 && unitInfos[num[0].unit].level
+}
+
+// returns true if any of the terms is a logarithmic level
+func containsLevel(terms []term) bool {
+	return slices.ContainsFunc(terms, func(t term) bool { return unitInfos[t.unit].level })
+}
+
+// Returns true if the quantity is expressed in a logarithmic unit, e.g. dB, Np or dBm
+func (q Qty) IsLogarithmic() bool {
+	return units[q.singleUnit()].kind == "logarithmic"
+}
+
+// returns the reference value of a level in base units
+func levelReference(unit string) (level, *Qty, error) {
+	l := levels[unit]
+	if ref, err := toBaseUnits(termsOf(l.numerator, unitIDs), termsOf(l.denominator, unitIDs)); err != nil {
+		return l, nil, err
+	} else {
+		return l, ref, nil
+	}
+}
+
+// converts a logarithmic level to the linear value it represents in base units, e.g. 10 dBm => 0.01 W
+func (q Qty) levelToBase() (*Qty, error) {
+	l, ref, err := levelReference(q.singleUnit())
+	if err != nil {
+		return nil, err
+	}
+	scalar := mulSafe(l.scalar, ref.scalar) * math.Pow(10, q.scalar/l.factor)
+	return ref.withScalar(scalar)
+}
+
+// converts a linear quantity or a level to a level, e.g. 10 mW => 10 dBm
+func toLevel(src, dst *Qty) (*Qty, error) {
+	l, ref, err := levelReference(dst.singleUnit())
+	if err != nil {
+		return nil, err
+	}
+	if src.baseScalar <= 0 {
+		return nil, fmt.Errorf("cannot convert %v to a logarithmic level", src)
+	}
+	if ratio, err := divSafe(src.baseScalar, mulSafe(l.scalar, ref.scalar)); err != nil {
+		return nil, err
+	} else {
+		return dst.withScalar(l.factor * math.Log10(ratio))
+	}
+}
+
+// converts a linear operand of a sum or difference with a level to the level, e.g. 10 mW => 10 dBm for 10 dBm + 10 mW
+// levels and relative logarithmic units like dB are returned as they are
+func linearToLevel(level, other *Qty) (*Qty, error) {
+	if other.IsLevel() || other.IsLogarithmic() {
+		return other, nil
+	} else if other.IsUnitless() {
+		return nil, fmt.Errorf("cannot combine a unitless ratio with a logarithmic level, use a gain in dB")
+	} else if !other.IsCompatible(level) {
+		return nil, fmt.Errorf("incompatible units: %v and %v", level.Units(), other.Units())
+	}
+	return other.To(level)
+}
+
+// adds two levels as a power sum, e.g. 10 dBm + 10 dBm => 13.01 dBm
+// or a level and a relative gain as a plain sum, e.g. 10 dBm + 3 dB => 13 dBm
+// A linear quantity is converted to the level first, e.g. 10 dBm + 10 mW => 13.01 dBm
+func addLevels(lhs, rhs *Qty) (*Qty, error) {
+	var err error
+	if lhs.IsLevel() {
+		rhs, err = linearToLevel(lhs, rhs)
+	} else {
+		lhs, err = linearToLevel(rhs, lhs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if lhs.IsLevel() && rhs.IsLevel() {
+		if !lhs.IsCompatible(rhs) {
+			return nil, fmt.Errorf("incompatible units: %v and %v", lhs.Units(), rhs.Units())
+		}
+		if r, err := rhs.To(lhs); err != nil {
+			return nil, err
+		} else {
+			sum := 10 * math.Log10(math.Pow(10, lhs.scalar/10)+math.Pow(10, r.scalar/10))
+			return lhs.withScalar(sum)
+		}
+	} else if lhs.IsLevel() {
+		return addLevelGain(lhs, rhs)
+	} else {
+		return addLevelGain(rhs, lhs)
+	}
+}
+
+func addLevelGain(level, gain *Qty) (*Qty, error) {
+	if g, err := gain.To("dB"); err != nil {
+		return nil, fmt.Errorf("incompatible units: %v and %v", level.Units(), gain.Units())
+	} else {
+		return level.withScalar(level.scalar + g.scalar)
+	}
+}
+
+// subtracts two levels giving their ratio in dB, e.g. 20 dBm - 10 dBm => 10 dB
+// or a relative gain from a level, e.g. 10 dBm - 3 dB => 7 dBm
+// A linear quantity is converted to the level first, e.g. 1 W - 20 dBm => 10 dB
+func subtractLevels(lhs, rhs *Qty) (*Qty, error) {
+	var err error
+	if lhs.IsLevel() {
+		rhs, err = linearToLevel(lhs, rhs)
+	} else {
+		lhs, err = linearToLevel(rhs, lhs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if lhs.IsLevel() && rhs.IsLevel() {
+		if !lhs.IsCompatible(rhs) {
+			return nil, fmt.Errorf("incompatible units: %v and %v", lhs.Units(), rhs.Units())
+		}
+		if r, err := rhs.To(lhs); err != nil {
+			return nil, err
+		} else {
+			return newQty(lhs.scalar-r.scalar, []string{"<decibel>"}, unityArray)
+		}
+	} else if lhs.IsLevel() {
+		if g, err := rhs.To("dB"); err != nil {
+			return nil, fmt.Errorf("incompatible units: %v and %v", lhs.Units(), rhs.Units())
+		} else {
+			return lhs.withScalar(lhs.scalar - g.scalar)
+		}
+	} else {
+		return nil, fmt.Errorf("cannot subtract a logarithmic level from a relative logarithmic unit")
+	}
+}
@@ -0,0 +1,196 @@
+package goqty
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLevelTo(t *testing.T) {
+	tests := map[string]struct {
+		expr   string
+		units  string
+		scalar float64
+	}{
+		"10 dBm -> mW":     {"10 dBm", "mW", 10},
+		"0 dBm -> mW":      {"0 dBm", "mW", 1},
+		"-30 dBm -> uW":    {"-30 dBm", "uW", 1},
+		"30 dBm -> W":      {"30 dBm", "W", 1},
+		"30 dBm -> dBW":    {"30 dBm", "dBW", 0},
+		"0 dBW -> dBm":     {"0 dBW", "dBm", 30},
+		"100 mW -> dBm":    {"100 mW", "dBm", 20},
+		"1 W -> dBW":       {"1 W", "dBW", 0},
+		"20 dBV -> V":      {"20 dBV", "V", 10},
+		"0 dBV -> dBuV":    {"0 dBV", "dBuV", 120},
+		"1 mV -> dBµV":     {"1 mV", "dBµV", 60},
+		"94 dBSPL -> Pa":   {"94 dBSPL", "Pa", 1.0024},
+		"20 uPa -> dBSPL":  {"20 uPa", "dBSPL", 0},
+		"1 Np -> dB":       {"1 Np", "dB", 8.685889638},
+		"2 bel -> dB":      {"2 bel", "dB", 20},
+		"20 dB -> Np":      {"20 dB", "Np", 2.302585093},
+		"10 dBm -> 10 dBm": {"10 dBm", "dBm", 10},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q, err := Parse(test.expr)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.expr, err)
+				return
+			}
+			if actual, err := q.To(test.units); err != nil {
+				t.Errorf("failed to convert %v to %v, got %v", test.expr, test.units, err)
+			} else if math.Abs(actual.scalar-test.scalar) > 1e-4 {
+				t.Errorf("expected scalar %v, got %v", test.scalar, actual.scalar)
+			}
+		})
+	}
+}
+
+func TestLevelToFailure(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		units    string
+		expected string
+	}{
+		"10 dBm -> V":   {"10 dBm", "V", "incompatible units: dBm and V"},
+		"10 dBm -> dBV": {"10 dBm", "dBV", "incompatible units: dBm and dBV"},
+		"10 dBm -> dB":  {"10 dBm", "dB", "incompatible units: dBm and dB"},
+		"0 W -> dBm":    {"0 W", "dBm", "cannot convert 0 W to a logarithmic level"},
+		"-1 W -> dBm":   {"-1 W", "dBm", "cannot convert -1 W to a logarithmic level"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			q, err := Parse(test.expr)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.expr, err)
+				return
+			}
+			if actual, err := q.To(test.units); err != nil {
+				if err.Error() != test.expected {
+					t.Errorf("expected %v, got %v", test.expected, err)
+				}
+			} else {
+				t.Errorf("expected error %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLevelArithmetic(t *testing.T) {
+	tests := map[string]struct {
+		a        string
+		op       string
+		b        string
+		expected string
+	}{
+		"10 dBm + 10 dBm": {"10 dBm", "+", "10 dBm", "13.01029995663981 dBm"},
+		"10 dBm + 3 dB":   {"10 dBm", "+", "3 dB", "13 dBm"},
+		"3 dB + 10 dBm":   {"3 dB", "+", "10 dBm", "13 dBm"},
+		"10 dBm + 1 bel":  {"10 dBm", "+", "1 bel", "20 dBm"},
+		"0 dBW + 30 dBm":  {"0 dBW", "+", "30 dBm", "3.010299956639812 dBW"},
+		"20 dBV + 20 dBV": {"20 dBV", "+", "20 dBV", "23.01029995663981 dBV"},
+		"3 dB + 3 dB":     {"3 dB", "+", "3 dB", "6 dB"},
+		"10 dBm + 10 dBV": {"10 dBm", "+", "10 dBV", "incompatible units: dBm and dBV"},
+		"10 dBm + 1 m":    {"10 dBm", "+", "1 m", "incompatible units: dBm and m"},
+		"10 dBm + 10 mW":  {"10 dBm", "+", "10 mW", "13.01029995663981 dBm"},
+		"10 mW + 10 dBm":  {"10 mW", "+", "10 dBm", "13.01029995663981 dBm"},
+		"10 dBm + 0.5":    {"10 dBm", "+", "0.5", "cannot combine a unitless ratio with a logarithmic level, use a gain in dB"},
+		"10 dBm + 0 W":    {"10 dBm", "+", "0 W", "cannot convert 0 W to a logarithmic level"},
+		"1 W - 20 dBm":    {"1 W", "-", "20 dBm", "10 dB"},
+		"20 dBm - 10 mW":  {"20 dBm", "-", "10 mW", "10 dB"},
+		"20 dBm - 0.5":    {"20 dBm", "-", "0.5", "cannot combine a unitless ratio with a logarithmic level, use a gain in dB"},
+		"20 dBm - 10 dBm": {"20 dBm", "-", "10 dBm", "10 dB"},
+		"30 dBm - 0 dBW":  {"30 dBm", "-", "0 dBW", "0 dB"},
+		"10 dBm - 10 dBV": {"10 dBm", "-", "10 dBV", "incompatible units: dBm and dBV"},
+		"20 dBm - 3 dB":   {"20 dBm", "-", "3 dB", "17 dBm"},
+		"3 dB - 20 dBm":   {"3 dB", "-", "20 dBm", "cannot subtract a logarithmic level from a relative logarithmic unit"},
+		"10 dBm * 2":      {"10 dBm", "*", "2", "cannot scale a logarithmic level, add a gain in dB instead"},
+		"2 * 10 dBm":      {"2", "*", "10 dBm", "cannot scale a logarithmic level, add a gain in dB instead"},
+		"10 dBm * 2 m":    {"10 dBm", "*", "2 m", "cannot multiply logarithmic levels"},
+		"10 dBm * 10 dBm": {"10 dBm", "*", "10 dBm", "cannot multiply logarithmic levels"},
+		"10 dBm / 2":      {"10 dBm", "/", "2", "cannot scale a logarithmic level, subtract a gain in dB instead"},
+		"10 dBm / 2 s":    {"10 dBm", "/", "2 s", "cannot divide with logarithmic levels"},
+		"1 W / 10 dBm":    {"1 W", "/", "10 dBm", "cannot divide with logarithmic levels"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, err := Parse(test.a)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.a, err)
+				return
+			}
+			var actual *Qty
+			switch test.op {
+			case "+":
+				actual, err = a.Add(test.b)
+			case "-":
+				actual, err = a.Sub(test.b)
+			case "*":
+				actual, err = a.Mul(test.b)
+			case "/":
+				actual, err = a.Div(test.b)
+			}
+			if err != nil {
+				if err.Error() != test.expected {
+					t.Errorf("expected %v, got %v", test.expected, err)
+				}
+			} else if str := actual.String(); str != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, str)
+			}
+		})
+	}
+}
+
+func TestLevelCompare(t *testing.T) {
+	a, _ := Parse("30 dBm")
+	if eq, err := a.Eq("1 W"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if !eq {
+		t.Errorf("expected 30 dBm to equal 1 W")
+	}
+	if lt, err := a.Lt("1 dBW"); err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if !lt {
+		t.Errorf("expected 30 dBm to be less than 1 dBW")
+	}
+	if a.Kind() != "power" {
+		t.Errorf("expected kind power, got %v", a.Kind())
+	}
+}
+
+func TestLevelFailure(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		expected string
+	}{
+		"dBm/Hz": {"10 dBm/Hz", "cannot combine logarithmic levels with other units"},
+		"dBm*s":  {"10 dBm*s", "cannot combine logarithmic levels with other units"},
+		"1/dBm":  {"10 1/dBm", "cannot combine logarithmic levels with other units"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if q, err := Parse(test.expr); err != nil {
+				if err.Error() != test.expected {
+					t.Errorf("expected %v, got %v", test.expected, err)
+				}
+			} else {
+				t.Errorf("expected error %v, got %v", test.expected, q)
+			}
+		})
+	}
+}
+
+func TestLevelSwiftConverter(t *testing.T) {
+	if converter, err := SwiftConverter("dBm", "mW"); err != nil {
+		t.Errorf("failed to create converter, got %v", err)
+	} else if actual, err := converter([]float64{0, 10, 20}); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else {
+		expected := []float64{1, 10, 100}
+		for i := range expected {
+			if math.Abs(actual[i]-expected[i]) > 1e-9 {
+				t.Errorf("expected %v, got %v", expected, actual)
+				break
+			}
+		}
+	}
+}
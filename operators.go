@@ -2,14 +2,18 @@ package goqty
 
 import (
 	"fmt"
+	"math"
+	"slices"
 )
 
-func (q *Qty) Add(input interface{}) (*Qty, error) {
+func (q Qty) Add(input interface{}) (*Qty, error) {
 	var other *Qty
 	var err error
 	switch t := input.(type) {
 	case *Qty:
 		other = input.(*Qty)
+	case Qty:
+		other = &t
 	case string:
 		if other, err = Parse(input.(string)); err != nil {
 			return nil, err
@@ -18,30 +22,36 @@ func (q *Qty) Add(input interface{}) (*Qty, error) {
 		return nil, fmt.Errorf("expecting string or *Qty, got %T", t)
 	}
 
+	if q.IsLevel() || other.IsLevel() {
+		return addLevels(&q, other)
+	}
+
 	if !q.IsCompatible(other) {
 		return nil, fmt.Errorf("incompatible units: %v and %v", q.Units(), other.Units())
 	}
 
-	if q.IsTemperature() && other.IsTemperature() {
-		return nil, fmt.Errorf("cannot add two temperatures")
-	} else if q.IsTemperature() {
-		return addTempDegrees(q, other)
-	} else if other.IsTemperature() {
-		return addTempDegrees(other, q)
+	if q.IsAffine() && other.IsAffine() {
+		return nil, fmt.Errorf("cannot add two %v", affineNoun(q.singleUnit()))
+	} else if q.IsAffine() {
+		return addAffineDifference(&q, other)
+	} else if other.IsAffine() {
+		return addAffineDifference(other, &q)
 	}
 	if to, err := other.To(q); err != nil {
 		return nil, err
 	} else {
-		return newQty(q.scalar+to.scalar, q.numerator, q.denominator)
+		return q.withScalar(q.scalar + to.scalar)
 	}
 }
 
-func (q *Qty) Sub(input interface{}) (*Qty, error) {
+func (q Qty) Sub(input interface{}) (*Qty, error) {
 	var other *Qty
 	var err error
 	switch t := input.(type) {
 	case *Qty:
 		other = input.(*Qty)
+	case Qty:
+		other = &t
 	case string:
 		if other, err = Parse(input.(string)); err != nil {
 			return nil, err
@@ -50,33 +60,42 @@ func (q *Qty) Sub(input interface{}) (*Qty, error) {
 		return nil, fmt.Errorf("expecting type string or *Qty, got %T", t)
 	}
 
+	if q.IsLevel() || other.IsLevel() {
+		return subtractLevels(&q, other)
+	}
+
 	if !q.IsCompatible(other) {
 		return nil, fmt.Errorf("incompatible units: %v and %v", q.Units(), other.Units())
 	}
 
-	if q.IsTemperature() && other.IsTemperature() {
-		return subtractTemperatures(q, other)
-	} else if q.IsTemperature() {
-		return subtractTempDegrees(q, other)
-	} else if other.IsTemperature() {
-		return nil, fmt.Errorf("cannot subtract a temperature from a differential degree unit")
+	if q.IsAffine() && other.IsAffine() {
+		return subtractAffine(&q, other)
+	} else if q.IsAffine() {
+		return subtractAffineDifference(&q, other)
+	} else if other.IsAffine() {
+		return nil, fmt.Errorf("cannot subtract an absolute %v from a difference", units[other.singleUnit()].kind)
 	}
 
 	if to, err := other.To(q); err != nil {
 		return nil, err
 	} else {
-		return newQty(q.scalar-to.scalar, q.numerator, q.denominator)
+		return q.withScalar(q.scalar - to.scalar)
 	}
 }
 
-func (q *Qty) Mul(input interface{}) (*Qty, error) {
+func (q Qty) Mul(input interface{}) (*Qty, error) {
 	var other *Qty
 	var err error
 	switch t := input.(type) {
 	case float64:
-		return newQty(mulSafe(input.(float64), q.scalar), q.numerator, q.denominator)
+		if q.IsLevel() {
+			return nil, fmt.Errorf("cannot scale a logarithmic level, add a gain in dB instead")
+		}
+		return q.withScalar(mulSafe(input.(float64), q.scalar))
 	case *Qty:
 		other = input.(*Qty)
+	case Qty:
+		other = &t
 	case string:
 		if other, err = Parse(input.(string)); err != nil {
 			return nil, err
@@ -85,30 +104,37 @@ func (q *Qty) Mul(input interface{}) (*Qty, error) {
 		return nil, fmt.Errorf("expecting float64, string, or *Qty, got %T", t)
 	}
 
-	if (q.IsTemperature() || other.IsTemperature()) && !(q.IsUnitless() || other.IsUnitless()) {
-		return nil, fmt.Errorf("cannot multiply by temperatures")
+	if q.IsAffine() && !other.IsUnitless() {
+		return nil, fmt.Errorf("cannot multiply by %v", affineNoun(q.singleUnit()))
+	} else if other.IsAffine() && !q.IsUnitless() {
+		return nil, fmt.Errorf("cannot multiply by %v", affineNoun(other.singleUnit()))
+	}
+	if (q.IsLevel() || other.IsLevel()) && (q.IsUnitless() || other.IsUnitless()) {
+		return nil, fmt.Errorf("cannot scale a logarithmic level, add a gain in dB instead")
+	} else if q.IsLevel() || other.IsLevel() {
+		return nil, fmt.Errorf("cannot multiply logarithmic levels")
 	}
 
 	// Quantities should be multiplied with same units if compatible, with base units else
-	op1 := q
+	op1 := &q
 	op2 := other
 
 	// so as not to confuse results, multiplication and division between temperature degrees will maintain original unit info in num/den
 	// multiplication and division between deg[CFRK] can never factor each other out, only themselves: "degK*degC/degC^2" == "degK/degC"
-	if op1.IsCompatible(op2) && op1.signature != 400 {
-		if op2, err = op2.To(op1.Units()); err != nil {
+	if op1.IsCompatible(op2) && op1.Dimension() != baseDimensions["temperature"] {
+		if op2, err = op2.To(op1.Unit()); err != nil {
 			return nil, err
 		}
 	}
-	if num, den, scale, err := cleanTerms(op1.numerator, op1.denominator, op2.numerator, op2.denominator); err != nil {
+	if num, den, scale, err := cleanTerms(op1.numTerms(), op1.denTerms(), op2.numTerms(), op2.denTerms()); err != nil {
 		return nil, err
 	} else {
 		scalar := mulSafe(op1.scalar, op2.scalar, scale)
-		return newQty(scalar, num, den)
+		return newQtyTerms(scalar, num, den)
 	}
 }
 
-func (q *Qty) Div(input interface{}) (*Qty, error) {
+func (q Qty) Div(input interface{}) (*Qty, error) {
 	var other *Qty
 	var err error
 	switch t := input.(type) {
@@ -116,11 +142,15 @@ func (q *Qty) Div(input interface{}) (*Qty, error) {
 		scalar := input.(float64)
 		if scalar == 0.0 {
 			return nil, fmt.Errorf("divide by zero")
+		} else if q.IsLevel() {
+			return nil, fmt.Errorf("cannot scale a logarithmic level, subtract a gain in dB instead")
 		} else {
-			return newQty(q.scalar/scalar, q.numerator, q.denominator)
+			return q.withScalar(q.scalar / scalar)
 		}
 	case *Qty:
 		other = input.(*Qty)
+	case Qty:
+		other = &t
 	case string:
 		if other, err = Parse(input.(string)); err != nil {
 			return nil, err
@@ -133,101 +163,87 @@ func (q *Qty) Div(input interface{}) (*Qty, error) {
 		return nil, fmt.Errorf("divide by zero")
 	}
 
-	if other.IsTemperature() {
-		return nil, fmt.Errorf("cannot divide with temperatures")
-	} else if q.IsTemperature() && !other.IsUnitless() {
-		return nil, fmt.Errorf("cannot divide with temperatures")
+	if other.IsAffine() {
+		return nil, fmt.Errorf("cannot divide with %v", affineNoun(other.singleUnit()))
+	} else if q.IsAffine() && !other.IsUnitless() {
+		return nil, fmt.Errorf("cannot divide with %v", affineNoun(q.singleUnit()))
+	}
+	if other.IsLevel() {
+		return nil, fmt.Errorf("cannot divide with logarithmic levels")
+	} else if q.IsLevel() && other.IsUnitless() {
+		return nil, fmt.Errorf("cannot scale a logarithmic level, subtract a gain in dB instead")
+	} else if q.IsLevel() {
+		return nil, fmt.Errorf("cannot divide with logarithmic levels")
 	}
 
 	// Quantities should be multiplied with same units if compatible, with base units else
-	op1 := q
+	op1 := &q
 	op2 := other
 
 	// so as not to confuse results, multiplication and division between temperature degrees will maintain original unit info in num/den
 	// multiplication and division between deg[CFRK] can never factor each other out, only themselves: "degK*degC/degC^2" == "degK/degC"
-	if op1.IsCompatible(op2) && op1.signature != 400 {
-		if op2, err = op2.To(op1.units); err != nil {
+	if op1.IsCompatible(op2) && op1.Dimension() != baseDimensions["temperature"] {
+		if op2, err = op2.To(op1.Unit()); err != nil {
 			return nil, err
 		}
 	}
-	if num, den, scale, err := cleanTerms(op1.numerator, op1.denominator, op2.denominator, op2.numerator); err != nil {
+	if num, den, scale, err := cleanTerms(op1.numTerms(), op1.denTerms(), op2.denTerms(), op2.numTerms()); err != nil {
 		return nil, err
 	} else {
-		return newQty(mulSafe(op1.scalar, scale)/op2.scalar, num, den)
+		return newQtyTerms(mulSafe(op1.scalar, scale)/op2.scalar, num, den)
 	}
 }
 
 // Returns a Qty that is the inverse of this Qty,
-func (q *Qty) Inverse() (*Qty, error) {
-	if q.IsTemperature() {
-		return nil, fmt.Errorf("cannot divide with temperatures")
+func (q Qty) Inverse() (*Qty, error) {
+	if q.IsAffine() {
+		return nil, fmt.Errorf("cannot divide with %v", affineNoun(q.singleUnit()))
+	}
+	if q.IsLevel() {
+		return nil, fmt.Errorf("cannot divide with logarithmic levels")
 	}
 	if q.scalar == 0 {
 		return nil, fmt.Errorf("divide by zero")
 	}
-	return newQty(1/q.scalar, q.denominator, q.numerator)
+	return newQtyTerms(1/q.scalar, q.denTerms(), q.numTerms())
 }
 
 type combinedType struct {
+	unit   unitID
 	dir    int
-	term   string
-	prefix string
+	prefix unitID
 	// scale factors
 	num float64
 	den float64
 }
 
-func cleanTerms(num1, den1, num2, den2 []string) (num []string, den []string, scale float64, err error) {
-	notUnity := func(val string) bool {
-		return val != unity
-	}
-
-	num1 = filter(num1, notUnity)
-	num2 = filter(num2, notUnity)
-	den1 = filter(den1, notUnity)
-	den2 = filter(den2, notUnity)
-
-	combined := make(map[string]combinedType)
-
-	combineTerms := func(terms []string, direction int) {
-		var k string
-		var prefix string
-		var prefixValue float64
-		for i := 0; i < len(terms); i++ {
-			term := terms[i]
-			if p, ok := prefixes[term]; ok {
-				// a unit with a prefix (i.e. <centi><meter>)
-				prefix = term
-				prefixValue = p.scalar
-				k = terms[i+1]
-				i++
-			} else {
-				// a unit with no prefix (eg <meter>)
-				prefix = ""
-				prefixValue = 1.0
-				k = term
+// combines the terms of a product or quotient, e.g. km*m/s => m^2/s with a scale of 1000
+// Terms of the same unit are converted to the prefix of the first one seen and cancel out.
+func cleanTerms(num1, den1, num2, den2 []term) (num []term, den []term, scale float64, err error) {
+	// the terms in the order in which they were first seen; the few terms don't need a map
+	var combined []combinedType
+
+	combineTerms := func(terms []term, direction int) {
+		for _, t := range terms {
+			i := slices.IndexFunc(combined, func(c combinedType) bool { return c.unit == t.unit })
+			if i < 0 {
+				combined = append(combined, combinedType{unit: t.unit, prefix: t.prefix, num: 1.0, den: 1.0})
+				i = len(combined) - 1
+				combined[i].dir += direction
+				t.power--
 			}
-			if k != "" && k != unity {
-				if c, ok := combined[k]; ok {
-					c.dir += direction
-					combinedPrefixValue := 1.0
-					if prefix, ok := prefixes[c.prefix]; ok {
-						combinedPrefixValue = prefix.scalar
-					}
-					if v, err := divSafe(prefixValue, combinedPrefixValue); err != nil {
-						// prefix scalars are never zero, so division by zero can't happen
-						// TODO return error?
-					} else {
-						if direction == 1 {
-							c.num *= v
-						} else {
-							c.den *= v
-						}
-						combined[k] = c
-					}
-				} else {
-					combined[k] = combinedType{dir: direction, term: k, prefix: prefix, num: 1.0, den: 1.0}
-				}
+			c := &combined[i]
+			c.dir += direction * t.power
+			if t.prefix == c.prefix || t.power == 0 {
+				continue
+			}
+			if v, err := divSafe(prefixScalar(t.prefix), prefixScalar(c.prefix)); err != nil {
+				// prefix scalars are never zero, so division by zero can't happen
+				// TODO return error?
+			} else if direction == 1 {
+				c.num *= powInt(v, t.power)
+			} else {
+				c.den *= powInt(v, t.power)
 			}
 		}
 	}
@@ -237,41 +253,121 @@ func cleanTerms(num1, den1, num2, den2 []string) (num []string, den []string, sc
 	combineTerms(num2, 1)
 	combineTerms(den2, -1)
 
-	num = []string{}
-	den = []string{}
 	scale = float64(1)
-
-	for _, v := range combined {
-		if v.dir > 0 {
-			for n := 0; n < v.dir; n++ {
-				if v.prefix == "" {
-					num = append(num, v.term)
-				} else {
-					num = append(num, v.prefix, v.term)
-				}
-			}
-		} else if v.dir < 0 {
-			for n := 0; n < -v.dir; n++ {
-				if v.prefix == "" {
-					den = append(den, v.term)
-				} else {
-					den = append(den, v.prefix, v.term)
-				}
+	for _, c := range combined {
+		if c.dir > 0 {
+			num = append(num, term{c.prefix, c.unit, c.dir})
+		} else if c.dir < 0 {
+			den = append(den, term{c.prefix, c.unit, -c.dir})
+		}
+		if c.num != c.den {
+			if s, err := divSafe(c.num, c.den); err != nil {
+				return nil, nil, 0, err
+			} else {
+				scale *= s
 			}
 		}
-		if s, err := divSafe(v.num, v.den); err != nil {
-			return nil, nil, 0, err
+	}
+	return num, den, scale, nil
+}
+
+// returns the scalar of a prefix, or 1 if there is no prefix
+func prefixScalar(prefix unitID) float64 {
+	if prefix == noUnit {
+		return 1
+	}
+	return unitInfos[prefix].scalar
+}
+
+// Returns a Qty raised to the integer power n, e.g. (3 m)^2 => 9 m^2
+// Negative powers invert the units, e.g. (2 s)^-1 => 0.5 1/s
+// The power must not be larger than MaxExponent.
+func (q Qty) Pow(n int) (*Qty, error) {
+	if err := checkExponent(n); err != nil {
+		return nil, err
+	}
+	if q.IsAffine() && n != 1 {
+		return nil, fmt.Errorf("cannot multiply by %v", affineNoun(q.singleUnit()))
+	}
+	if q.IsLevel() && n != 1 {
+		return nil, fmt.Errorf("cannot multiply logarithmic levels")
+	}
+	if n < 0 && q.scalar == 0 {
+		return nil, fmt.Errorf("divide by zero")
+	}
+
+	// check before multiplying so that repeated powers can't overflow
+	tooLarge := func(t term) bool { return t.power > maxPower/abs(n) }
+	if n != 0 && (slices.ContainsFunc(q.numTerms(), tooLarge) || slices.ContainsFunc(q.denTerms(), tooLarge)) {
+		return nil, fmt.Errorf("unit power is larger than %v", maxPower)
+	}
+
+	num := powTerms(q.numTerms(), abs(n))
+	den := powTerms(q.denTerms(), abs(n))
+	if n < 0 {
+		num, den = den, num
+	}
+	return newQtyTerms(math.Pow(q.scalar, float64(n)), num, den)
+}
+
+// Returns the n-th root of a Qty, e.g. root 2 of 9 m^2 => 3 m
+// It is an error if the units can't be evenly divided by n, e.g. root 2 of m^3
+// The root must not be larger than MaxExponent.
+func (q Qty) Root(n int) (*Qty, error) {
+	if n == 0 {
+		return nil, fmt.Errorf("divide by zero")
+	}
+	if err := checkExponent(n); err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		if i, err := q.Inverse(); err != nil {
+			return nil, err
 		} else {
-			scale *= s
+			return i.Root(-n)
 		}
 	}
+	if q.IsAffine() && n != 1 {
+		return nil, fmt.Errorf("cannot multiply by %v", affineNoun(q.singleUnit()))
+	}
+	if q.IsLevel() && n != 1 {
+		return nil, fmt.Errorf("cannot multiply logarithmic levels")
+	}
 
-	if len(num) == 0 {
-		num = unityArray
+	var scalar float64
+	if q.scalar >= 0 {
+		scalar = nthRoot(q.scalar, n)
+	} else if n%2 == 1 {
+		scalar = -nthRoot(-q.scalar, n)
+	} else {
+		return nil, fmt.Errorf("cannot take an even root of a negative number")
 	}
-	if len(den) == 0 {
-		den = unityArray
+
+	num, ok := rootTerms(q.numTerms(), n)
+	if !ok {
+		return nil, fmt.Errorf("units %v are not a perfect power of %v", q.Units(), n)
+	}
+	den, ok := rootTerms(q.denTerms(), n)
+	if !ok {
+		return nil, fmt.Errorf("units %v are not a perfect power of %v", q.Units(), n)
 	}
+	return newQtyTerms(scalar, num, den)
+}
 
-	return num, den, scale, nil
+// returns an error if the exponent is larger than MaxExponent, which bounds the size of the units of a power
+func checkExponent(n int) error {
+	if n > MaxExponent || n < -MaxExponent {
+		return fmt.Errorf("exponent %v is larger than %v", n, MaxExponent)
+	}
+	return nil
+}
+
+// Returns the square root of a Qty, e.g. 9 m^2 => 3 m
+func (q Qty) Sqrt() (*Qty, error) {
+	return q.Root(2)
+}
+
+// Returns the cube root of a Qty, e.g. 8 m^3 => 2 m
+func (q Qty) Cbrt() (*Qty, error) {
+	return q.Root(3)
 }
@@ -163,6 +163,9 @@ func TestMul(t *testing.T) {
 		"3 A/km * 4 m":   {"3 A/km", "4m", "0.012 A"},
 		"4 m * 3 1/km^2": {"4m", "3 1/km^2", "0.000012 1/m"},
 		"3 1/km^2 * 4m":  {"3 1/km^2", "4m", "0.012 1/km"},
+		// the same prefix doesn't scale the result
+		"2 am * 3 am":   {"2 am", "3 am", "6 am^2"},
+		"2 Zm * 3 Zm/s": {"2 Zm", "3 Zm/s", "6 Zm^2/s"},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -253,3 +256,177 @@ func TestDiv(t *testing.T) {
 		})
 	}
 }
+
+func TestPow(t *testing.T) {
+	tests := map[string]struct {
+		a        string
+		n        int
+		expected string
+	}{
+		"(3 m)^2":     {"3 m", 2, "9 m^2"},
+		"(2 m/s)^3":   {"2 m/s", 3, "8 m^3/s^3"},
+		"(2 km)^2":    {"2 km", 2, "4 km^2"},
+		"(2 s)^-1":    {"2 s", -1, "0.5 1/s"},
+		"(4 m)^0":     {"4 m", 0, "1"},
+		"(0 m)^-1":    {"0 m", -1, "divide by zero"},
+		"(2 m)^5":     {"2 m", 5, "32 m^5"},
+		"(2 tempC)^2": {"2 tempC", 2, "cannot multiply by temperatures"},
+		"(2 degC)^2":  {"2 degC", 2, "4 °C^2"},
+		"(3 N*m/s)^2": {"3 N*m/s", 2, "9 m^2*N^2/s^2"},
+		"(1.5)^2":     {"1.5", 2, "2.25"},
+		"(2 tempC)^1": {"2 tempC", 1, "2 tempC"},
+		"(2 m)^20":    {"2 m", 20, "1048576 m^20"},
+		"(2 m)^21":    {"2 m", 21, "exponent 21 is larger than 20"},
+		"(2 m)^-21":   {"2 m", -21, "exponent -21 is larger than 20"},
+		"(1 m)^1e7":   {"1 m", 10_000_000, "exponent 10000000 is larger than 20"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, err := Parse(test.a)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.a, err)
+				return
+			}
+			if actual, err := a.Pow(test.n); err != nil {
+				if err.Error() != test.expected {
+					t.Errorf("expected %v, got %v", test.expected, err)
+				}
+			} else {
+				str := actual.String()
+				if str != test.expected {
+					t.Errorf("expected %v, got %v", test.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+// powers of powers have units with large exponents, which must not be expanded into a term for every power
+func TestPowLargeUnits(t *testing.T) {
+	q, _ := Parse("1 km/s")
+	for i := 0; i < 4; i++ {
+		var err error
+		if q, err = q.Pow(20); err != nil {
+			t.Fatalf("failed, got %v", err)
+		}
+	}
+	if q.Units() != "km^160000/s^160000" {
+		t.Errorf("expected km^160000/s^160000, got %v", q.Units())
+	}
+	if p, err := q.Mul(q); err != nil {
+		t.Errorf("failed, got %v", err)
+	} else if p.Units() != "km^320000/s^320000" {
+		t.Errorf("expected km^320000/s^320000, got %v", p.Units())
+	}
+	if _, err := q.Pow(20); err == nil || err.Error() != "unit power is larger than 1048576" {
+		t.Errorf("expected unit power is larger than 1048576, got %v", err)
+	}
+}
+
+// repeated powers must not overflow the powers of the units
+func TestPowOverflow(t *testing.T) {
+	q, _ := Parse("1 m")
+	var err error
+	for i := 0; i < 16 && err == nil; i++ {
+		q, err = q.Pow(20)
+	}
+	if err == nil || err.Error() != "unit power is larger than 1048576" {
+		t.Errorf("expected unit power is larger than 1048576, got %v", err)
+	}
+}
+
+func TestRoot(t *testing.T) {
+	tests := map[string]struct {
+		a        string
+		n        int
+		expected string
+	}{
+		"sqrt 9 m^2":      {"9 m^2", 2, "3 m"},
+		"sqrt 16 m^2/s^2": {"16 m^2/s^2", 2, "4 m/s"},
+		"sqrt 4 km^2":     {"4 km^2", 2, "2 km"},
+		"cbrt 8 m^3":      {"8 m^3", 3, "2 m"},
+		"cbrt -8 m^3":     {"-8 m^3", 3, "-2 m"},
+		"sqrt -4 m^2":     {"-4 m^2", 2, "cannot take an even root of a negative number"},
+		"sqrt m^3":        {"8 m^3", 2, "units m^3 are not a perfect power of 2"},
+		"sqrt 4 tempC":    {"4 tempC", 2, "cannot multiply by temperatures"},
+		"root 0":          {"4 m", 0, "divide by zero"},
+		"root -2":         {"4 m^2", -2, "0.5 1/m"},
+		"sqrt 2.25":       {"2.25", 2, "1.5"},
+		"root 21":         {"1 m", 21, "exponent 21 is larger than 20"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, err := Parse(test.a)
+			if err != nil {
+				t.Errorf("failed to parse %v, got %v", test.a, err)
+				return
+			}
+			if actual, err := a.Root(test.n); err != nil {
+				if err.Error() != test.expected {
+					t.Errorf("expected %v, got %v", test.expected, err)
+				}
+			} else {
+				str := actual.String()
+				if str != test.expected {
+					t.Errorf("expected %v, got %v", test.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestSqrtCbrt(t *testing.T) {
+	a, err := Parse("9 m^2")
+	if err != nil {
+		t.Errorf("failed to parse 9 m^2, got %v", err)
+		return
+	}
+	if actual, err := a.Sqrt(); err != nil {
+		t.Errorf("failed to take square root, got %v", err)
+	} else if actual.String() != "3 m" {
+		t.Errorf("expected 3 m, got %v", actual)
+	}
+	b, err := Parse("27 m^3")
+	if err != nil {
+		t.Errorf("failed to parse 27 m^3, got %v", err)
+		return
+	}
+	if actual, err := b.Cbrt(); err != nil {
+		t.Errorf("failed to take cube root, got %v", err)
+	} else if actual.String() != "3 m" {
+		t.Errorf("expected 3 m, got %v", actual)
+	}
+}
+
+func BenchmarkMul(b *testing.B) {
+	force, _ := Parse("5 kg*m/s^2")
+	length, _ := Parse("3 km")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := force.Mul(length); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDiv(b *testing.B) {
+	length, _ := Parse("3 km")
+	time, _ := Parse("2 h")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := length.Div(time); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkIsCompatible(b *testing.B) {
+	speed, _ := Parse("60 mi/h")
+	other, _ := Parse("20 m/s")
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if !speed.IsCompatible(other) {
+			b.Fatal("expected compatible units")
+		}
+	}
+}
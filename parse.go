@@ -1,171 +1,559 @@
 package goqty
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
-	"sort"
+	"slices"
 	"strconv"
 	"strings"
-	"sync"
+	"unicode"
+	"unicode/utf8"
 )
 
-const sign = "[+-]"
-const integer = "\\d+"
-const signedInteger = sign + "?" + integer
-const fraction = "\\." + integer
-const float = "(?:" + integer + "(?:" + fraction + ")?" + ")" +
-	"|" +
-	"(?:" + fraction + ")"
-const exponent = "[Ee]" + signedInteger
-const sciNumber = "(?:" + float + ")(?:" + exponent + ")?"
-const signedNumber = sign + "?\\s*" + sciNumber
-const qtyString = "(" + signedNumber + ")?\\s*([^/]*)(?:/(.+))?"
+var prefixTrie = newAliasTrie(prefixesByAlias)
+var unitTrie = newAliasTrie(unitsByAlias)
 
-var qtyStringRegex = regexp.MustCompile("^" + qtyString + "$")
+// scientific notation written with a multiplication sign after the scalar, e.g. 1.5×10³ or 1.5·10^3
+var timesTenRegex = regexp.MustCompile("^\\s*[×·⋅]\\s*10(?:(?:\\^|\\*\\*)([-−]?[0-9]+)|([⁻⁺]?[⁰¹²³⁴⁵⁶⁷⁸⁹]+))")
 
-const powerOp = "\\^|\\*{2}"
-const safePower = "[0-9]+"
+// fractional scalars, e.g. 3/8 or 2 1/2 or ¾ or 2¾
+var fractionRegex = regexp.MustCompile("^([+-]?)\\s*(?:([0-9]+)\\s+)?([0-9]+)\\s*[/⁄]\\s*([0-9]+)(.*)$")
+var vulgarFractionRegex = regexp.MustCompile("^([+-]?)\\s*([0-9]+)?\\s*([½⅓⅔¼¾⅕⅖⅗⅘⅙⅚⅐⅛⅜⅝⅞⅑⅒↉])(.*)$")
 
-var topRegex = regexp.MustCompile("([^ \\*\\d]+?)(?:" + powerOp + ")?(-?" + safePower + ")")
-var notTopRegex = regexp.MustCompile("([^ \\*\\d]+?)(?:" + powerOp + ")?(-?" + safePower + "[a-zA-Z])")
-var bottomRegex = regexp.MustCompile("([^ \\*\\d]+?)(?:" + powerOp + ")?(" + safePower + ")")
-var notBottomRegex = regexp.MustCompile("([^ \\*\\d]+?)(?:" + powerOp + ")?(" + safePower + "[a-zA-Z])")
-
-var prefix = re(prefixesByAlias)
-var unit = re(unitsByAlias)
-var boundary = "\\b|$" // TODO \b only supports ASCII
-var unitMatch = "(" + prefix + ")??(" + unit + ")(?:" + boundary + ")"
-var unitTestRegex = regexp.MustCompile("\\s*(" + unitMatch + "[\\s\\*]*)")
+var vulgarFractions = map[string][2]float64{
+	"½": {1, 2}, "⅓": {1, 3}, "⅔": {2, 3}, "¼": {1, 4}, "¾": {3, 4},
+	"⅕": {1, 5}, "⅖": {2, 5}, "⅗": {3, 5}, "⅘": {4, 5}, "⅙": {1, 6}, "⅚": {5, 6},
+	"⅐": {1, 7}, "⅛": {1, 8}, "⅜": {3, 8}, "⅝": {5, 8}, "⅞": {7, 8}, "⅑": {1, 9}, "⅒": {1, 10}, "↉": {0, 3},
+}
 
-var wsRegex = regexp.MustCompile(`\\s`)
+// MaxExponent is the largest unit exponent accepted by Parse, Pow and Root.
+// It bounds the size of the units that hostile input can create.
+var MaxExponent = 20
 
-var parsedUnitsCache sync.Map
+// the tokens of unit atoms, e.g. km => <kilo>,<meter>
+var parsedUnitsCache = newLRUCache[string, []string](4096)
 
 /* parse a string into a unit object.
  * Typical formats like :
  * "5.6 kg*m/s^2"
  * "5.6 kg*m*s^-2"
  * "5.6 kilogram*meter*second^-2"
+ * "5.6 kg/(m*s^2)"
+ * "9.8 m/s/s"
+ * "4 (m/s)^2"
+ * "4 m²·s⁻¹"
+ * "1.5×10³ Pa"
+ * "3/8 in", "2 1/2 ft", "¾ cup"  -- fractions and mixed numbers
  * "2.2 kPa"
  * "37 degC"
  * "1"  -- creates a unitless constant with value 1
  * "GPa"  -- creates a unit with scalar 1 with units 'GPa'
- * 6'4"  -- recognized as 6 feet + 4 inches
- * 8 lbs 8 oz -- recognized as 8 lbs + 8 ounces
  */
 func Parse(expr string) (*Qty, error) {
-	result := Qty{
-		scalar:      1,
-		numerator:   unityArray,
-		denominator: unityArray,
+	return ParseWithOptions(expr, ParseOptions{})
+}
+
+/* Parses a string like Parse, but also understands English unit phrases, e.g.
+ * "60 miles per hour"
+ * "3 square feet"
+ * "2 cubic meters"
+ * "9.8 meters per second squared"
+ * and plurals that aren't aliases, e.g. "5 newtons"
+ */
+func ParseLenient(expr string) (*Qty, error) {
+	return ParseWithOptions(expr, ParseOptions{Lenient: true})
+}
+
+// ParseOptions changes how ParseWithOptions parses a quantity.
+// The zero value parses like Parse.
+type ParseOptions struct {
+	// The kinds the quantity must be, e.g. mass; any kind is accepted if empty
+	Kinds []string
+	// The units of a quantity written without units, e.g. a bare 12 in a field labelled weight (kg)
+	DefaultUnit Unit
+	// The largest unit exponent, MaxExponent if 0
+	MaxExponent int
+	// Understand English unit phrases like ParseLenient
+	Lenient bool
+	// Require units to be multiplied with an operator, e.g. N*m but not N m
+	DisallowImplicitMultiplication bool
+	// Reject whitespace inside the scalar, e.g. between the sign and the number in - 5 m
+	DisallowScalarWhitespace bool
+	// Aliases that are rejected because they are ambiguous, as written including any prefix, e.g. min or Gal
+	DisallowedAliases []string
+	// Reject text that isn't a unit, e.g. the kg in kgs, which is otherwise skipped so kgs is s
+	DisallowUnrecognizedText bool
+}
+
+// Parses a string like Parse, with options for the context the string comes from, e.g.
+// ParseWithOptions("12", ParseOptions{Kinds: []string{"mass"}, DefaultUnit: Kilogram})
+func ParseWithOptions(expr string, opts ParseOptions) (*Qty, error) {
+	value, units, err := parseScalar(strings.TrimSpace(expr), opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Lenient {
+		if units, err = rewritePhrases(units); err != nil {
+			return nil, err
+		}
+	}
+
+	var num, den []term
+	if strings.TrimSpace(units) == "" && opts.DefaultUnit != (Unit{}) {
+		num, den = Qty{expr: opts.DefaultUnit.expr}.numTerms(), Qty{expr: opts.DefaultUnit.expr}.denTerms()
+	} else if num, den, err = parseUnitExpr(units, opts); err != nil {
+		return nil, err
+	}
+
+	result, err := newQtyTerms(value, num, den)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Kinds) > 0 && !slices.Contains(opts.Kinds, result.Kind()) {
+		if len(opts.Kinds) == 1 {
+			return nil, fmt.Errorf("expected %v, got %v", opts.Kinds[0], result.Kind())
+		}
+		return nil, fmt.Errorf("expected one of %v, got %v", strings.Join(opts.Kinds, ", "), result.Kind())
 	}
+	return result, nil
+}
 
-	expr = strings.TrimSpace(expr)
-	qtyMatches := qtyStringRegex.FindStringSubmatch(expr)
-	if qtyMatches == nil {
-		return nil, fmt.Errorf("quantity not recognized: %v", expr)
+// splits an expression into its scalar, which defaults to 1, and its units
+func parseScalar(expr string, opts ParseOptions) (float64, string, error) {
+	if v, units, ok, err := parseFraction(expr); err != nil {
+		return 0, "", err
+	} else if ok {
+		return v, units, nil
 	}
-	scalar := qtyMatches[1]
-	top := qtyMatches[2]
-	bottom := qtyMatches[3]
 
+	scalar, units := splitScalar(expr)
+
+	value := 1.0
 	if scalar != "" {
+		if opts.DisallowScalarWhitespace && strings.ContainsFunc(scalar, unicode.IsSpace) {
+			return 0, "", fmt.Errorf("quantity not recognized: %v", expr)
+		}
 		// Allow whitespaces between sign and scalar for loose parsing
-		scalarMatch := wsRegex.ReplaceAllString(scalar, "")
-		result.scalar, _ = strconv.ParseFloat(scalarMatch, 64)
-	} else {
-		result.scalar = 1
+		scalarMatch := strings.Join(strings.Fields(scalar), "")
+		if m := timesTenRegex.FindStringSubmatch(units); m != nil {
+			exponent := m[1]
+			if exponent == "" {
+				exponent = fromSuperscript(m[2])
+			}
+			scalarMatch += "e" + strings.ReplaceAll(exponent, "−", "-")
+			units = units[len(m[0]):]
+		}
+		var err error
+		if value, err = strconv.ParseFloat(scalarMatch, 64); err != nil {
+			return 0, "", fmt.Errorf("quantity not recognized: %v", expr)
+		}
 	}
+	return value, units, nil
+}
 
-	var err error
-	var n int64
-	var x, nx string
-	for {
-		matches := topRegex.FindStringSubmatch(top)
-		if matches == nil {
-			break
-		}
-		// alternative to negative lookahead, allows for units like cmH2O
-		if m := notTopRegex.FindStringSubmatch(top); m != nil {
-			break
-		}
-		unit := matches[1]
-		power := matches[2]
-
-		// Prevents infinite loops
-		if n, err = strconv.ParseInt(power, 10, 8); err != nil {
-			return nil, fmt.Errorf("unit exponent is not a number")
-		} else if n > 4 {
-			return nil, fmt.Errorf("unit not recognized")
-		}
-		//Disallow unrecognized unit even if exponent is 0
-		if unitTestRegex.FindString(unit) == "" {
-			return nil, fmt.Errorf("unit not recognized")
-		}
-		x = unit + " "
-		nx = ""
-		for i := 0; i < int(n); i++ {
-			nx += x
-		}
-		if n >= 0 {
-			top = strings.ReplaceAll(top, matches[0], nx)
-		} else {
-			if bottom == "" {
-				bottom = nx
+// splits a leading number from the rest of an expression, e.g. -5.6e3 kg => -5.6e3 and kg
+// The sign may be separated from the number by whitespace, e.g. - 5 m.
+// An expression that doesn't start with a number has no scalar, e.g. kg or - m.
+func splitScalar(expr string) (string, string) {
+	i := 0
+	if i < len(expr) && (expr[i] == '+' || expr[i] == '-') {
+		i++
+		for i < len(expr) && unicode.IsSpace(rune(expr[i])) {
+			i++
+		}
+	}
+	digits := scanDigits(expr, i)
+	if digits < len(expr) && expr[digits] == '.' {
+		// 5.6 or 5. or .5, but not a lone .
+		if end := scanDigits(expr, digits+1); digits > i || end > digits+1 {
+			digits = end
+		}
+	}
+	if digits == i {
+		return "", expr
+	}
+	i = digits
+	if i < len(expr) && (expr[i] == 'e' || expr[i] == 'E') {
+		// an exponent, but only if it has digits, so 5 em is 5 em
+		j := i + 1
+		if j < len(expr) && (expr[j] == '+' || expr[j] == '-') {
+			j++
+		}
+		if end := scanDigits(expr, j); end > j {
+			i = end
+		}
+	}
+	return expr[:i], strings.TrimLeftFunc(expr[i:], unicode.IsSpace)
+}
+
+// returns the offset of the first byte from start that isn't an ASCII digit
+func scanDigits(s string, start int) int {
+	for start < len(s) && s[start] >= '0' && s[start] <= '9' {
+		start++
+	}
+	return start
+}
+
+// rewrites English unit phrases into a unit expression, e.g. meters per second squared => meters / second^2
+func rewritePhrases(units string) (string, error) {
+	var result []string
+	power := ""
+	for _, word := range strings.Fields(units) {
+		switch strings.ToLower(word) {
+		case "per":
+			if power != "" {
+				return "", fmt.Errorf("unit not recognized")
+			}
+			result = append(result, "/")
+		case "square", "sq":
+			power = "^2"
+		case "cubic":
+			power = "^3"
+		case "squared", "cubed":
+			if len(result) == 0 || result[len(result)-1] == "/" {
+				return "", fmt.Errorf("unit not recognized")
+			}
+			if strings.ToLower(word) == "squared" {
+				result[len(result)-1] = "(" + result[len(result)-1] + ")^2"
 			} else {
-				bottom = bottom + nx
+				result[len(result)-1] = "(" + result[len(result)-1] + ")^3"
 			}
-			top = strings.ReplaceAll(top, matches[0], "")
+		default:
+			if power != "" {
+				word = "(" + singular(word) + ")" + power
+				power = ""
+			} else {
+				word = singular(word)
+			}
+			result = append(result, word)
+		}
+	}
+	if power != "" {
+		return "", fmt.Errorf("unit not recognized")
+	}
+	return strings.Join(result, " "), nil
+}
+
+// returns a unit word as an alias that can be parsed, e.g. newtons => newton, Miles => miles
+func singular(word string) string {
+	// try the word as written first, since case matters for prefixes, e.g. MW is not mW
+	candidates := []string{word, strings.ToLower(word)}
+	for _, w := range []string{word, strings.ToLower(word)} {
+		candidates = append(candidates, strings.TrimSuffix(w, "s"), strings.TrimSuffix(w, "es"))
+		if strings.HasSuffix(w, "ies") {
+			candidates = append(candidates, strings.TrimSuffix(w, "ies")+"y")
+		}
+	}
+	for _, c := range candidates {
+		if _, _, err := parseUnitExpr(c, ParseOptions{DisallowUnrecognizedText: true}); err == nil {
+			return c
 		}
 	}
+	// leave it to the parser to report
+	return word
+}
+
+// parses a scalar written as a fraction or a mixed number, e.g. 3/8 or 2 1/2 or 2¾,
+// returns the value, the rest of the expression and false if the scalar isn't a fraction
+func parseFraction(expr string) (float64, string, bool, error) {
+	var sign, whole, units string
+	var num, den float64
+	if m := fractionRegex.FindStringSubmatch(expr); m != nil {
+		sign, whole, units = m[1], m[2], m[5]
+		num, _ = strconv.ParseFloat(m[3], 64)
+		den, _ = strconv.ParseFloat(m[4], 64)
+	} else if m := vulgarFractionRegex.FindStringSubmatch(expr); m != nil {
+		sign, whole, units = m[1], m[2], m[4]
+		num, den = vulgarFractions[m[3]][0], vulgarFractions[m[3]][1]
+	} else {
+		return 0, "", false, nil
+	}
+
+	if den == 0 {
+		return 0, "", false, fmt.Errorf("divide by zero")
+	}
+	value := num / den
+	if whole != "" {
+		w, _ := strconv.ParseFloat(whole, 64)
+		value += w
+	}
+	if sign == "-" {
+		value = -value
+	}
+	return value, units, true, nil
+}
+
+/* Parses a unit expression into numerator and denominator terms.
+ * Multiplication is written with * or whitespace and binds tighter than division,
+ * which is left associative, so kg/m*s^2 and kg/m/s^2 are both kg/(m*s^2).
+ * Exponents apply to a unit or a parenthesized group, e.g. m^2, m2, s^-1 or (m/s)^2.
+ *
+ *   quotient = [product] { "/" product }
+ *   product  = factor { ["*" | "·" | "⋅" | "×"] factor }
+ *   factor   = ( "(" quotient ")" | unit ) [ ("^" | "**") exponent | superscript exponent ]
+ */
+func parseUnitExpr(units string, opts ParseOptions) ([]term, []term, error) {
+	p := unitParser{runes: []rune(units), opts: opts}
+	num, den, err := p.quotient()
+	if err != nil {
+		return nil, nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.runes) {
+		if p.runes[p.pos] == ')' {
+			return nil, nil, fmt.Errorf("unbalanced parentheses in %v", units)
+		}
+		return nil, nil, fmt.Errorf("unit not recognized")
+	}
+	return num, den, nil
+}
+
+type unitParser struct {
+	runes []rune
+	pos   int
+	opts  ParseOptions
+}
+
+func (p *unitParser) skipSpace() {
+	for p.pos < len(p.runes) && unicode.IsSpace(p.runes[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *unitParser) peek() rune {
+	if p.pos < len(p.runes) {
+		return p.runes[p.pos]
+	}
+	return 0
+}
 
+func (p *unitParser) quotient() ([]term, []term, error) {
+	num, den, err := p.product()
+	if err != nil {
+		return nil, nil, err
+	}
+	for {
+		p.skipSpace()
+		if p.peek() != '/' {
+			return num, den, nil
+		}
+		p.pos++
+		n, d, err := p.product()
+		if err != nil {
+			return nil, nil, err
+		} else if len(n) == 0 && len(d) == 0 {
+			return nil, nil, fmt.Errorf("unit not recognized")
+		}
+		num, den = addTerms(num, d), addTerms(den, n)
+	}
+}
+
+func (p *unitParser) product() ([]term, []term, error) {
+	var num, den []term
 	for {
-		matches := bottomRegex.FindStringSubmatch(bottom)
-		if matches == nil {
-			break
+		p.skipSpace()
+		n, d, ok, err := p.factor()
+		if err != nil {
+			return nil, nil, err
+		} else if !ok {
+			return num, den, nil
 		}
-		// alternative to negative lookahead, allows for units like cmH2O
-		if m := notBottomRegex.FindStringSubmatch(bottom); m != nil {
-			break
+		num, den = addTerms(num, n), addTerms(den, d)
+		p.skipSpace()
+		if isMultiplication(p.peek()) {
+			p.pos++
+			p.skipSpace()
+			if c := p.peek(); c == 0 || c == '/' || c == ')' || isMultiplication(c) {
+				return nil, nil, fmt.Errorf("unit not recognized")
+			}
+		} else if c := p.peek(); p.opts.DisallowImplicitMultiplication && c != 0 && c != '/' && c != ')' {
+			return nil, nil, fmt.Errorf("implicit multiplication is not allowed")
 		}
-		unit := matches[1]
-		power := matches[2]
+	}
+}
 
-		if n, err = strconv.ParseInt(power, 10, 8); err != nil {
-			return nil, fmt.Errorf("unit exponenent is not a number")
+// parses a unit or a parenthesized group with an optional exponent, returns false if there is none
+func (p *unitParser) factor() ([]term, []term, bool, error) {
+	var num, den []term
+	var err error
+	if p.peek() == '(' {
+		p.pos++
+		if num, den, err = p.quotient(); err != nil {
+			return nil, nil, false, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return nil, nil, false, fmt.Errorf("unbalanced parentheses")
 		}
-		if unitTestRegex.FindString(unit) == "" {
-			return nil, fmt.Errorf("unit is not recognized")
+		p.pos++
+	} else {
+		start := p.pos
+		for p.pos < len(p.runes) && !isUnitSeparator(p.runes[p.pos]) {
+			p.pos++
 		}
-		x = unit + " "
-		nx = ""
-		for j := 0; j < int(n); j++ {
-			nx += x
+		if p.pos == start {
+			return nil, nil, false, nil
 		}
-		if n >= 0 {
-			bottom = strings.ReplaceAll(bottom, matches[0], nx)
+		if num, den, err = p.unitWithExponent(string(p.runes[start:p.pos])); err != nil {
+			return nil, nil, false, err
+		}
+	}
+
+	var exponent string
+	if p.peek() == '^' || p.peek() == '*' && p.pos+1 < len(p.runes) && p.runes[p.pos+1] == '*' {
+		if p.peek() == '^' {
+			p.pos++
 		} else {
-			if bottom == "" {
-				bottom = nx
-			} else {
-				bottom = bottom + nx
+			p.pos += 2
+		}
+		start := p.pos
+		if p.peek() == '-' || p.peek() == '−' {
+			p.pos++
+		}
+		for p.pos < len(p.runes) && unicode.IsDigit(p.runes[p.pos]) {
+			p.pos++
+		}
+		exponent = strings.ReplaceAll(string(p.runes[start:p.pos]), "−", "-")
+	} else if _, ok := superscripts[p.peek()]; ok {
+		start := p.pos
+		for _, ok := superscripts[p.peek()]; ok; _, ok = superscripts[p.peek()] {
+			p.pos++
+		}
+		exponent = fromSuperscript(string(p.runes[start:p.pos]))
+	} else {
+		return num, den, true, nil
+	}
+	if n, err := p.exponent(exponent); err != nil {
+		return nil, nil, false, err
+	} else {
+		num, den = powerTerms(num, den, n)
+		if slices.ContainsFunc(num, largePower) || slices.ContainsFunc(den, largePower) {
+			// nested groups multiply their exponents, e.g. ((m^20)^20)^20
+			return nil, nil, false, fmt.Errorf("unit power is larger than %v", maxPower)
+		}
+		return num, den, true, nil
+	}
+}
+
+func isUnitSeparator(r rune) bool {
+	_, superscript := superscripts[r]
+	return unicode.IsSpace(r) || strings.ContainsRune("()/^", r) || isMultiplication(r) || superscript
+}
+
+// * or the middle dot, dot operator and multiplication sign, e.g. N·m
+func isMultiplication(r rune) bool {
+	return r == '*' || r == '·' || r == '⋅' || r == '×'
+}
+
+var superscripts = map[rune]rune{
+	'⁰': '0', '¹': '1', '²': '2', '³': '3', '⁴': '4', '⁵': '5', '⁶': '6', '⁷': '7', '⁸': '8', '⁹': '9',
+	'⁻': '-', '⁺': '+',
+}
+
+// converts superscript digits and signs to ASCII, e.g. ⁻¹ => -1
+func fromSuperscript(s string) string {
+	var result strings.Builder
+	for _, r := range s {
+		if c, ok := superscripts[r]; ok {
+			result.WriteRune(c)
+		} else {
+			result.WriteRune(r)
+		}
+	}
+	return result.String()
+}
+
+// converts ASCII digits and signs to superscript, e.g. -1 => ⁻¹
+func toSuperscript(s string) string {
+	var result strings.Builder
+	for _, r := range s {
+		for sup, c := range superscripts {
+			if c == r {
+				r = sup
+				break
 			}
-			bottom = strings.ReplaceAll(bottom, matches[0], "")
 		}
+		result.WriteRune(r)
 	}
+	return result.String()
+}
 
-	if top != "" {
-		if result.numerator, err = parseUnits(strings.TrimSpace(top)); err != nil {
-			return nil, err
+// parses a single unit with an optional exponent written without ^, e.g. m or m2 or s-1
+func (p *unitParser) unitWithExponent(text string) ([]term, []term, error) {
+	if slices.Contains(p.opts.DisallowedAliases, text) {
+		return nil, nil, fmt.Errorf("ambiguous unit %v is not allowed", text)
+	}
+	if terms, err := parseUnits(text); err == nil {
+		return termsOf(terms, unitIDs), nil, nil
+	}
+	unit, exponent, ok := splitExponent(text)
+	if !ok {
+		if terms, ok := p.scanUnits(text); ok {
+			return termsOf(terms, unitIDs), nil, nil
 		}
+		return nil, nil, fmt.Errorf("unit not recognized")
 	}
-	if bottom != "" {
-		if result.denominator, err = parseUnits(strings.TrimSpace(bottom)); err != nil {
-			return nil, err
+	if slices.Contains(p.opts.DisallowedAliases, unit) {
+		return nil, nil, fmt.Errorf("ambiguous unit %v is not allowed", unit)
+	}
+	n, err := p.exponent(exponent)
+	if err != nil {
+		return nil, nil, err
+	}
+	terms, err := parseUnits(unit)
+	if err != nil {
+		if terms, ok = p.scanUnits(unit); !ok {
+			return nil, nil, err
 		}
 	}
+	num, den := powerTerms(termsOf(terms, unitIDs), nil, n)
+	return num, den, nil
+}
+
+// splits the trailing exponent from a unit, e.g. m2 => m and 2 or s-1 => s and -1
+func splitExponent(text string) (string, string, bool) {
+	start := len(text)
+	for start > 0 && text[start-1] >= '0' && text[start-1] <= '9' {
+		start--
+	}
+	if start == len(text) {
+		return "", "", false
+	} else if start > 1 && text[start-1] == '-' {
+		start--
+	} else if start == 0 {
+		// all digits, the first is the unit, e.g. the unitless 1 in 12
+		start = 1
+	}
+	if start == len(text) {
+		return "", "", false
+	}
+	return text[:start], text[start:], true
+}
+
+// parses a unit exponent, which is limited to MaxExponent unless the options say otherwise
+func (p *unitParser) exponent(text string) (int, error) {
+	max := MaxExponent
+	if p.opts.MaxExponent > 0 {
+		max = p.opts.MaxExponent
+	}
+	if n, err := strconv.ParseInt(text, 10, 64); err != nil && !errors.Is(err, strconv.ErrRange) {
+		return 0, fmt.Errorf("unit exponent is not a number")
+	} else if err != nil || n > int64(max) || n < -int64(max) {
+		// Prevents hostile input from expanding into huge powers
+		return 0, fmt.Errorf("unit exponent %v is not between -%v and %v", text, max, max)
+	} else {
+		return int(n), nil
+	}
+}
 
-	return newQty(result.scalar, result.numerator, result.denominator)
+// raises unit terms to an integer power, e.g. (m/s)^2 => m^2/s^2
+func powerTerms(num, den []term, n int) ([]term, []term) {
+	num, den = powTerms(num, abs(n)), powTerms(den, abs(n))
+	if n < 0 {
+		return den, num
+	}
+	return num, den
 }
 
 /* Parses and convers units string to normalized units array.
@@ -173,37 +561,83 @@ func Parse(expr string) (*Qty, error) {
  */
 func parseUnits(units string) ([]string, error) {
 	if cached, found := parsedUnitsCache.Load(units); found {
-		return cached.([]string), nil
+		return cached, nil
 	}
 
-	matches := unitTestRegex.FindAllStringSubmatch(units, -1)
-	if len(matches) == 0 {
+	result, ok := lookupUnits(units)
+	if !ok {
 		return nil, fmt.Errorf("unit not recognized")
 	}
-	result := make([]string, 0)
-	for _, match := range matches {
-		prefix, hasPrefix := prefixesByAlias[match[2]]
-		unit, hasUnit := unitsByAlias[match[3]]
+	return parsedUnitsCache.LoadOrStore(units, result), nil
+}
 
-		if hasPrefix && hasUnit {
-			result = append(result, prefix, unit)
-		} else if hasUnit {
-			result = append(result, unit)
+// finds a single unit with an optional prefix, e.g. km => <kilo>,<meter>
+// A whole unit alias wins over a prefixed unit, so min is a minute and not a milli-inch,
+// otherwise the longest prefix that leaves a unit wins.
+func lookupUnits(text string) ([]string, bool) {
+	if name, ok := unitTrie.lookup(text); ok {
+		return []string{name}, true
+	}
+	prefixes := prefixTrie.prefixesOf(text)
+	for i := len(prefixes) - 1; i >= 0; i-- {
+		if name, ok := unitTrie.lookup(text[prefixes[i].end:]); ok {
+			return []string{prefixes[i].name, name}, true
 		}
 	}
-	parsedUnitsCache.Store(units, result)
-	return result, nil
+	return nil, false
 }
 
-func re(unitsByAlias map[string]string) string {
-	keys := make([]string, len(unitsByAlias))
-	i := 0
-	for k := range unitsByAlias {
-		keys[i] = k
-		i++
+// finds the units in text that isn't a single unit, skipping the text around them like the regex parser did, e.g. kgs => <second>
+// At every offset a unit without a prefix wins over a prefixed unit and the longest alias wins,
+// but only if it ends at a word boundary.
+func (p *unitParser) scanUnits(text string) ([]string, bool) {
+	if p.opts.DisallowUnrecognizedText {
+		return nil, false
+	}
+	var result []string
+	for i := 0; i < len(text); {
+		if name, end, ok := scanUnit(text, i); ok {
+			result = append(result, name)
+			i = end
+			continue
+		}
+		found := false
+		for _, prefix := range slices.Backward(prefixTrie.prefixesOf(text[i:])) {
+			if name, end, ok := scanUnit(text, i+prefix.end); ok {
+				result = append(result, prefix.name, name)
+				i, found = end, true
+				break
+			}
+		}
+		if !found {
+			_, size := utf8.DecodeRuneInString(text[i:])
+			i += size
+		}
 	}
-	sort.SliceStable(keys, func(i int, j int) bool {
-		return len(keys[i]) > len(keys[j])
-	})
-	return strings.Join(keys, "|")
+	return result, len(result) > 0
+}
+
+// returns the longest unit alias at offset i of text that ends at a word boundary
+func scanUnit(text string, i int) (string, int, bool) {
+	for _, unit := range slices.Backward(unitTrie.prefixesOf(text[i:])) {
+		if end := i + unit.end; end == len(text) || isWordRune(lastRune(text[:end])) != isWordRune(firstRune(text[end:])) {
+			return unit.name, end, true
+		}
+	}
+	return "", 0, false
+}
+
+// whether r is a word character, a letter, digit or underscore, so that e.g. µm isn't found in aµm
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func firstRune(s string) rune {
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
+
+func lastRune(s string) rune {
+	r, _ := utf8.DecodeLastRuneInString(s)
+	return r
 }
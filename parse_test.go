@@ -23,6 +23,8 @@ func TestParse(t *testing.T) {
 		// simple
 		"1.5m":  {"1.5m", "1.5 m", 1.5, "length", []string{"<meter>"}, []string{"<1>"}},
 		"-1.5m": {"-1.5m", "-1.5 m", -1.5, "length", []string{"<meter>"}, []string{"<1>"}},
+		"5. m":  {"5. m", "5 m", 5, "length", []string{"<meter>"}, []string{"<1>"}},
+		".5 m":  {".5 m", "0.5 m", 0.5, "length", []string{"<meter>"}, []string{"<1>"}},
 		// denominator
 		"1.5 /m": {"1.5 /m", "1.5 1/m", 1.5, "wavenumber", []string{"<1>"}, []string{"<meter>"}},
 		"-1.5/m": {"-1.5 /m", "-1.5 1/m", -1.5, "wavenumber", []string{"<1>"}, []string{"<meter>"}},
@@ -36,10 +38,52 @@ func TestParse(t *testing.T) {
 		"1 \u2126":  {"1 \u2126", "1 \u2126", 1, "resistance", []string{"<ohm>"}, []string{"<1>"}}, // ohm
 		"1 \u00b0":  {"1 \u00b0", "1 \u00b0", 1, "angle", []string{"<degree>"}, []string{"<1>"}},
 		"1 \u00b0C": {"1 \u00b0C", "1 \u00b0C", 1, "temperature", []string{"<celsius>"}, []string{"<1>"}},
+		// a whole alias wins over a prefixed unit, otherwise the longest prefix
+		"min":   {"5 min", "5 min", 5, "time", []string{"<minute>"}, []string{"<1>"}},
+		"dam":   {"5 dam", "5 dam", 5, "length", []string{"<deca>", "<meter>"}, []string{"<1>"}},
+		"cmH2O": {"5 cmH2O", "5 cmH2O", 5, "pressure", []string{"<cmh2o>"}, []string{"<1>"}},
+		// text around a unit is skipped
+		"kgs":   {"5 kgs", "5 s", 5, "time", []string{"<second>"}, []string{"<1>"}},
+		"ft/ss": {"1 ft/ss", "1 ft/s", 1, "speed", []string{"<foot>"}, []string{"<second>"}},
 		// compound
-		"5 N*m":  {"5 N*m", "5 N*m", 5, "energy", []string{"<newton>", "<meter>"}, []string{"<1>"}},
+		"5 N*m":  {"5 N*m", "5 m*N", 5, "energy", []string{"<meter>", "<newton>"}, []string{"<1>"}},
 		"3 A/km": {"3 A/km", "3 A/km", 3, "magnetism", []string{"<ampere>"}, []string{"<kilo>", "<meter>"}},
 		"1 m/s":  {"1 m/s", "1 m/s", 1, "speed", []string{"<meter>"}, []string{"<second>"}},
+		// grouping
+		"kg/(m*s^2)":   {"1 kg/(m*s^2)", "1 kg/m*s^2", 1, "pressure", []string{"<kilogram>"}, []string{"<meter>", "<second>", "<second>"}},
+		"W/(m^2*degK)": {"2 W/(m^2*degK)", "2 W/°K*m^2", 2, "power/temperature·area", []string{"<watt>"}, []string{"<kelvin>", "<meter>", "<meter>"}},
+		"m/s/s":        {"9.8 m/s/s", "9.8 m/s^2", 9.8, "acceleration", []string{"<meter>"}, []string{"<second>", "<second>"}},
+		"(m/s)^2":      {"4 (m/s)^2", "4 m^2/s^2", 4, "radiation", []string{"<meter>", "<meter>"}, []string{"<second>", "<second>"}},
+		"(m/s)^-1":     {"4 (m/s)^-1", "4 s/m", 4, "length⁻¹·time", []string{"<second>"}, []string{"<meter>"}},
+		"1/(m*s)":      {"3 1/(m*s)", "3 1/m*s", 3, "length⁻¹·time⁻¹", []string{"<1>"}, []string{"<meter>", "<second>"}},
+		"kg m/s^2":     {"5 kg m/s^2", "5 kg*m/s^2", 5, "force", []string{"<kilogram>", "<meter>"}, []string{"<second>", "<second>"}},
+		"s^-1":         {"5 s^-1", "5 1/s", 5, "frequency", []string{"<1>"}, []string{"<second>"}},
+		"m2":           {"5 m2", "5 m^2", 5, "area", []string{"<meter>", "<meter>"}, []string{"<1>"}},
+		// unicode operators and superscripts
+		"m²":        {"5 m²", "5 m^2", 5, "area", []string{"<meter>", "<meter>"}, []string{"<1>"}},
+		"s⁻¹":       {"5 s⁻¹", "5 1/s", 5, "frequency", []string{"<1>"}, []string{"<second>"}},
+		"m^−1":      {"5 m^−1", "5 1/m", 5, "wavenumber", []string{"<1>"}, []string{"<meter>"}},
+		"N·m":       {"5 N·m", "5 m*N", 5, "energy", []string{"<meter>", "<newton>"}, []string{"<1>"}},
+		"kg⋅m":      {"5 kg⋅m", "5 kg*m", 5, "length·mass", []string{"<kilogram>", "<meter>"}, []string{"<1>"}},
+		"N×m":       {"5 N×m", "5 m*N", 5, "energy", []string{"<meter>", "<newton>"}, []string{"<1>"}},
+		"W/m²·degK": {"2 W/m²·degK", "2 W/°K*m^2", 2, "power/temperature·area", []string{"<watt>"}, []string{"<kelvin>", "<meter>", "<meter>"}},
+		"(m/s)²":    {"4 (m/s)²", "4 m^2/s^2", 4, "radiation", []string{"<meter>", "<meter>"}, []string{"<second>", "<second>"}},
+		"Ω·m":       {"2 Ω·m", "2 m*\u2126", 2, "resistivity", []string{"<meter>", "<ohm>"}, []string{"<1>"}},
+		// scientific notation
+		"1.5×10³ Pa":    {"1.5×10³ Pa", "1500 Pa", 1500, "pressure", []string{"<pascal>"}, []string{"<1>"}},
+		"1.5 × 10^3 Pa": {"1.5 × 10^3 Pa", "1500 Pa", 1500, "pressure", []string{"<pascal>"}, []string{"<1>"}},
+		"2·10⁻³ m":      {"2·10⁻³ m", "0.002 m", 0.002, "length", []string{"<meter>"}, []string{"<1>"}},
+		"3⋅10**2":       {"3⋅10**2", "300", 300, "unitless", []string{"<1>"}, []string{"<1>"}},
+		// fractions
+		"3/8 in":    {"3/8 in", "0.375 in", 0.375, "length", []string{"<inch>"}, []string{"<1>"}},
+		"2 1/2 ft":  {"2 1/2 ft", "2.5 ft", 2.5, "length", []string{"<foot>"}, []string{"<1>"}},
+		"-2 1/2 ft": {"-2 1/2 ft", "-2.5 ft", -2.5, "length", []string{"<foot>"}, []string{"<1>"}},
+		"1/2":       {"1/2", "0.5", 0.5, "unitless", []string{"<1>"}, []string{"<1>"}},
+		"1/4 m/s":   {"1/4 m/s", "0.25 m/s", 0.25, "speed", []string{"<meter>"}, []string{"<second>"}},
+		"3⁄4 in":    {"3⁄4 in", "0.75 in", 0.75, "length", []string{"<inch>"}, []string{"<1>"}},
+		"¾ cup":     {"¾ cup", "0.75 cu", 0.75, "volume", []string{"<cup>"}, []string{"<1>"}},
+		"2½ in":     {"2½ in", "2.5 in", 2.5, "length", []string{"<inch>"}, []string{"<1>"}},
+		"2 ¼ in":    {"2 ¼ in", "2.25 in", 2.25, "length", []string{"<inch>"}, []string{"<1>"}},
 		// pressure (negative lookahead)
 		"1 inH2O": {"1 inH2O", "1 inH2O", 1, "pressure", []string{"<inh2o>"}, []string{"<1>"}},
 		"1 cmH2O": {"1 cmH2O", "1 cmH2O", 1, "pressure", []string{"<cmh2o>"}, []string{"<1>"}},
@@ -61,11 +105,11 @@ func TestParse(t *testing.T) {
 				if kind != test.kind {
 					t.Errorf("expected kind %v, got %v", test.kind, kind)
 				}
-				if !slices.Equal(q.numerator, test.numerator) {
-					t.Errorf("expected numerator %v, got %v", test.numerator, q.numerator)
+				if !slices.Equal(q.Numerator(), test.numerator) {
+					t.Errorf("expected numerator %v, got %v", test.numerator, q.Numerator())
 				}
-				if !slices.Equal(q.denominator, test.denominator) {
-					t.Errorf("expected denominator %v, got %v", test.denominator, q.denominator)
+				if !slices.Equal(q.Denominator(), test.denominator) {
+					t.Errorf("expected denominator %v, got %v", test.denominator, q.Denominator())
 				}
 			}
 		})
@@ -80,11 +124,21 @@ func TestParseFailure(t *testing.T) {
 		// temperature division
 		// "2 tempF/s":               {"2 tempF/s", "cannot divide with temperatures"},
 		// "2 s/tempF":               {"2 2 s/tempF", "cannot divide with temperatures"},
-		"593720475cm^4939207503":  {"593720475cm^4939207503", "unit exponent is not a number"},
-		"593720475cm**4939207503": {"593720475cm**4939207503", "unit exponent is not a number"},
-		"593720475cm^5":           {"593720475cm^5", "unit not recognized"},
-		"593720475cm**55":         {"593720475cm**55", "unit not recognized"},
+		"593720475cm^4939207503":  {"593720475cm^4939207503", "unit exponent 4939207503 is not between -20 and 20"},
+		"593720475cm**4939207503": {"593720475cm**4939207503", "unit exponent 4939207503 is not between -20 and 20"},
+		"593720475cm^21":          {"593720475cm^21", "unit exponent 21 is not between -20 and 20"},
+		"593720475cm**55":         {"593720475cm**55", "unit exponent 55 is not between -20 and 20"},
 		"aa":                      {"aa", "unit not recognized"},
+		"kg/(m*s":                 {"1 kg/(m*s", "unbalanced parentheses"},
+		"kg/m)":                   {"1 kg/m)", "unbalanced parentheses in kg/m)"},
+		"m/":                      {"1 m/", "unit not recognized"},
+		"m**":                     {"1 m*", "unit not recognized"},
+		"(m/s)^99":                {"1 (m/s)^99", "unit exponent 99 is not between -20 and 20"},
+		"nested powers":           {"1 ((((m^20)^20)^20)^20)^20", "unit power is larger than 1048576"},
+		"m²²":                     {"1 m²²", "unit exponent 22 is not between -20 and 20"},
+		"N··m":                    {"1 N··m", "unit not recognized"},
+		"non-ASCII word":          {"5 méter", "unit not recognized"},
+		"1/0 in":                  {"1/0 in", "divide by zero"},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -99,3 +153,153 @@ func TestParseFailure(t *testing.T) {
 		})
 	}
 }
+
+func TestParseLenient(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		expected string
+		strict   bool // whether strict parsing also succeeds
+	}{
+		"per":              {"60 miles per hour", "60 mi/h", false},
+		"square":           {"3 square feet", "3 ft^2", false},
+		"sq":               {"3 sq ft", "3 ft^2", false},
+		"cubic":            {"2 cubic meters", "2 m^3", false},
+		"squared":          {"9.8 meters per second squared", "9.8 m/s^2", false},
+		"cubed":            {"1 meter cubed", "1 m^3", false},
+		"per cubic":        {"1000 kilograms per cubic meter", "1000 kg/m^3", false},
+		"plural":           {"5 newtons", "5 N", false},
+		"plural compound":  {"5 newtons per square meter", "5 N/m^2", false},
+		"capitalized":      {"5 Miles", "5 mi", false},
+		"per per":          {"1 meter per second per second", "1 m/s^2", false},
+		"strict units":     {"5 kg*m/s^2", "5 kg*m/s^2", true},
+		"strict grouping":  {"5 W/(m^2*degK)", "5 W/°K*m^2", true},
+		"fraction phrases": {"1/2 cubic feet", "0.5 ft^3", false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if q, err := ParseLenient(test.expr); err != nil {
+				t.Errorf("failed to parse %v, got %v", test.expr, err)
+			} else if q.String() != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, q)
+			}
+			strict := ParseOptions{DisallowUnrecognizedText: true}
+			if _, err := ParseWithOptions(test.expr, strict); (err == nil) != test.strict {
+				t.Errorf("expected strict parsing of %v to succeed %v, got %v", test.expr, test.strict, err)
+			}
+		})
+	}
+}
+
+func TestParseLenientFailure(t *testing.T) {
+	tests := map[string]string{
+		"dangling square": "3 square",
+		"dangling per":    "3 m per",
+		"leading squared": "3 squared",
+		"square per":      "3 square per m",
+		"unknown":         "3 qq per hour",
+	}
+	for name, expr := range tests {
+		t.Run(name, func(t *testing.T) {
+			if q, err := ParseLenient(expr); err == nil {
+				t.Errorf("expected error, got %v", q)
+			}
+		})
+	}
+}
+
+func TestParseWithOptions(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		opts     ParseOptions
+		expected string
+	}{
+		"zero options":         {"5 kg*m/s^2", ParseOptions{}, "5 kg*m/s^2"},
+		"default unit":         {"12", ParseOptions{DefaultUnit: Kilogram}, "12 kg"},
+		"default unit ignored": {"12 lbs", ParseOptions{DefaultUnit: Kilogram}, "12 lbs"},
+		"kind":                 {"12 lbs", ParseOptions{Kinds: []string{"mass"}}, "12 lbs"},
+		"kinds":                {"12 m", ParseOptions{Kinds: []string{"mass", "length"}}, "12 m"},
+		"kind and default":     {"12", ParseOptions{Kinds: []string{"mass"}, DefaultUnit: Kilogram}, "12 kg"},
+		"max exponent":         {"1 m^6", ParseOptions{MaxExponent: 6}, "1 m^6"},
+		"large max exponent":   {"1 m^200", ParseOptions{MaxExponent: 200}, "1 m^200"},
+		"lenient":              {"60 miles per hour", ParseOptions{Lenient: true}, "60 mi/h"},
+		"explicit multiply":    {"5 N*m", ParseOptions{DisallowImplicitMultiplication: true}, "5 m*N"},
+		"grouped multiply":     {"5 W/(m^2*degK)", ParseOptions{DisallowImplicitMultiplication: true}, "5 W/°K*m^2"},
+		"scalar whitespace":    {"- 5 m", ParseOptions{}, "-5 m"},
+		"compact scalar":       {"-5 m", ParseOptions{DisallowScalarWhitespace: true}, "-5 m"},
+		"allowed alias":        {"5 minute", ParseOptions{DisallowedAliases: []string{"min"}}, "5 min"},
+		"recognized text":      {"5 kg/s^2", ParseOptions{DisallowUnrecognizedText: true}, "5 kg/s^2"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if q, err := ParseWithOptions(test.expr, test.opts); err != nil {
+				t.Errorf("failed to parse %v, got %v", test.expr, err)
+			} else if q.String() != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, q)
+			}
+		})
+	}
+}
+
+func TestParseWithOptionsFailure(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		opts     ParseOptions
+		expected string
+	}{
+		"kind":                  {"12 m", ParseOptions{Kinds: []string{"mass"}}, "expected mass, got length"},
+		"kinds":                 {"12 s", ParseOptions{Kinds: []string{"mass", "length"}}, "expected one of mass, length, got time"},
+		"unitless kind":         {"12", ParseOptions{Kinds: []string{"mass"}}, "expected mass, got unitless"},
+		"max exponent":          {"1 m^3", ParseOptions{MaxExponent: 2}, "unit exponent 3 is not between -2 and 2"},
+		"large exponent":        {"1 m^-300", ParseOptions{MaxExponent: 200}, "unit exponent -300 is not between -200 and 200"},
+		"exponent overflow":     {"1 m^99999999999999999999", ParseOptions{}, "unit exponent 99999999999999999999 is not between -20 and 20"},
+		"strict phrases":        {"60 miles per hour", ParseOptions{}, "unit not recognized"},
+		"implicit multiply":     {"5 N m", ParseOptions{DisallowImplicitMultiplication: true}, "implicit multiplication is not allowed"},
+		"scalar whitespace":     {"- 5 m", ParseOptions{DisallowScalarWhitespace: true}, "quantity not recognized: - 5 m"},
+		"disallowed alias":      {"5 min", ParseOptions{DisallowedAliases: []string{"min"}}, "ambiguous unit min is not allowed"},
+		"disallowed with power": {"5 min^2", ParseOptions{DisallowedAliases: []string{"min"}}, "ambiguous unit min is not allowed"},
+		"disallowed in product": {"5 m/min", ParseOptions{DisallowedAliases: []string{"min"}}, "ambiguous unit min is not allowed"},
+		"unrecognized text":     {"5 kgs", ParseOptions{DisallowUnrecognizedText: true}, "unit not recognized"},
+		"unrecognized power":    {"1 ft/ss2", ParseOptions{DisallowUnrecognizedText: true}, "unit not recognized"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if q, err := ParseWithOptions(test.expr, test.opts); err == nil {
+				t.Errorf("expected error %v, got %v", test.expected, q)
+			} else if err.Error() != test.expected {
+				t.Errorf("expected error %v, got %v", test.expected, err)
+			}
+		})
+	}
+}
+
+var benchmarkExprs = []string{
+	"5.6 kg*m/s^2",
+	"9.8 m/s/s",
+	"4 (m/s)^2",
+	"1.5×10³ Pa",
+	"-12.5e3 kilowatt*hour",
+	"72 degF",
+	"3 attoparsec/microfortnight",
+}
+
+func BenchmarkParse(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for _, expr := range benchmarkExprs {
+			if _, err := Parse(expr); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// looks up unit atoms without the parsed units cache, which is what untrusted input mostly hits
+func BenchmarkLookupUnits(b *testing.B) {
+	atoms := []string{"m", "kg", "km", "kilometer", "µm", "Ω", "MΩ", "degC", "cmH2O", "inHg", "Mbps", "attoparsec", "microfortnight", "furlong", "ft", "kWh"}
+	for i := 0; i < b.N; i++ {
+		for _, atom := range atoms {
+			if _, ok := lookupUnits(atom); !ok {
+				b.Fatal(atom)
+			}
+		}
+	}
+}
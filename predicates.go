@@ -1,20 +1,18 @@
 package goqty
 
 import (
-	"regexp"
 	"slices"
 )
 
-func (q *Qty) IsUnitless() bool {
-	return slices.Compare(q.numerator, unityArray) == 0 &&
-		slices.Compare(q.denominator, unityArray) == 0
+func (q Qty) IsUnitless() bool {
+	return q.expr == nil
 }
 
-func (q *Qty) IsCompatible(other *Qty) bool {
-	return q.signature == other.signature
+func (q Qty) IsCompatible(other *Qty) bool {
+	return q.Dimension() == other.Dimension()
 }
 
-func (q *Qty) IsInverse(other *Qty) bool {
+func (q Qty) IsInverse(other *Qty) bool {
 	if i, err := q.Inverse(); err != nil {
 		return false
 	} else {
@@ -22,25 +20,13 @@ func (q *Qty) IsInverse(other *Qty) bool {
 	}
 }
 
-func (q *Qty) IsBase() bool {
-	if q.isBase != 0 {
-		return q.isBase == 1
-	}
-
-	if q.IsDegrees() && regexp.MustCompile("<(kelvin|temp-K)>").MatchString(q.numerator[0]) {
-		q.isBase = 1
-		return true
-	}
+func (q Qty) IsBase() bool {
+	return q.expr == nil || q.expr.isBase
+}
 
-	units := slices.Concat(q.numerator, q.denominator)
-	for _, u := range units {
-		if u != unity && !slices.Contains(baseUnits, u) {
-			q.isBase = -1
-		}
-	}
-	if q.isBase == -1 {
-		return false
-	}
-	q.isBase = 1
-	return true
+// returns true if all of the units are base units without prefixes
+func computeIsBase(num, den []term) bool {
+	isBase := func(t term) bool { return t.prefix == noUnit && unitInfos[t.unit].base }
+	return !slices.ContainsFunc(num, func(t term) bool { return !isBase(t) }) &&
+		!slices.ContainsFunc(den, func(t term) bool { return !isBase(t) })
 }
@@ -10,8 +10,8 @@ func TestInit(t *testing.T) {
 	if err != nil {
 		t.Errorf("unexpected error %v", err)
 	}
-	if len(qty.numerator) != 1 || qty.numerator[0] != "<meter>" {
-		t.Errorf("got %q, wanted %q", qty.numerator[0], "<meter>")
+	if len(qty.Numerator()) != 1 || qty.Numerator()[0] != "<meter>" {
+		t.Errorf("got %q, wanted %q", qty.Numerator(), "<meter>")
 	}
 	if qty.scalar != 1 {
 		t.Errorf("got %v, wanted %v", qty.scalar, 1)
@@ -26,11 +26,11 @@ func TestUnitless(t *testing.T) {
 	if qty.scalar != 1 {
 		t.Errorf("got %v, wanted %v", qty.scalar, 1.0)
 	}
-	if !slices.Equal(qty.numerator, []string{"<1>"}) {
-		t.Errorf("got %v, wanted %v", qty.numerator, "[<1>]")
+	if !slices.Equal(qty.Numerator(), []string{"<1>"}) {
+		t.Errorf("got %v, wanted %v", qty.Numerator(), "[<1>]")
 	}
-	if !slices.Equal(qty.denominator, []string{"<1>"}) {
-		t.Errorf("got %v, wanted %v", qty.denominator, "[<1>]")
+	if !slices.Equal(qty.Denominator(), []string{"<1>"}) {
+		t.Errorf("got %v, wanted %v", qty.Denominator(), "[<1>]")
 	}
 	qty, err = Parse("1.5")
 	if err != nil {
@@ -39,10 +39,10 @@ func TestUnitless(t *testing.T) {
 	if qty.scalar != 1.5 {
 		t.Errorf("got %v, wanted %v", qty.scalar, 1.5)
 	}
-	if !slices.Equal(qty.numerator, []string{"<1>"}) {
-		t.Errorf("got %v, wanted %v", qty.numerator, "[<1>]")
+	if !slices.Equal(qty.Numerator(), []string{"<1>"}) {
+		t.Errorf("got %v, wanted %v", qty.Numerator(), "[<1>]")
 	}
-	if !slices.Equal(qty.denominator, []string{"<1>"}) {
-		t.Errorf("got %v, wanted %v", qty.denominator, "[<1>]")
+	if !slices.Equal(qty.Denominator(), []string{"<1>"}) {
+		t.Errorf("got %v, wanted %v", qty.Denominator(), "[<1>]")
 	}
 }
@@ -1,65 +1,169 @@
 package goqty
 
 import (
-	"math"
 	"slices"
+	"strconv"
+	"strings"
 )
 
-var signatureTypes = []string{"length", "time", "temperature", "mass", "current", "substance", "luminosity", "currency", "information", "angle"}
+// Dimension is the exponents of the base quantities of a unit, e.g. N is {Length: 1, Mass: 1, Time: -2}.
+// Dimensions are comparable, and quantities are compatible if their dimensions are equal.
+type Dimension struct {
+	Length      int
+	Mass        int
+	Time        int
+	Current     int
+	Temperature int
+	Substance   int
+	Luminosity  int
+	Currency    int
+	Information int
+	Angle       int
+	SolidAngle  int
+	Count       int
+}
 
-// calculates the unit signature id for use in comparing compatible units and simplification
-// the signature is based on a simple classification of units and is based on the following publication
+// the dimensions of the kinds of units that are base quantities
+var baseDimensions = map[string]Dimension{
+	"length":      {Length: 1},
+	"mass":        {Mass: 1},
+	"time":        {Time: 1},
+	"current":     {Current: 1},
+	"temperature": {Temperature: 1},
+	"substance":   {Substance: 1},
+	"luminosity":  {Luminosity: 1},
+	"currency":    {Currency: 1},
+	"information": {Information: 1},
+	"angle":       {Angle: 1},
+	"solid_angle": {SolidAngle: 1},
+	"counting":    {Count: 1},
+}
 
-// Novak, G.S., Jr. "Conversion of units of measurement", IEEE Transactions on Software Engineering,
-// 21(8), Aug 1995, pp.651-661
-// doi://10.1109/32.403789
-// http://ieeexplore.ieee.org/Xplore/login.jsp?url=/iel1/32/9079/00403789.pdf?isnumber=9079&prod=JNL&arnumber=403789&arSt=651&ared=661&arAuthor=Novak%2C+G.S.%2C+Jr.
-func (q *Qty) unitSignature() (int, error) {
-	if q.signature != 0 && !q.IsUnitless() {
-		return q.signature, nil
-	}
+// the names of the base quantities, in the order of the fields of Dimension
+var dimensionNames = []string{"length", "mass", "time", "current", "temperature", "substance",
+	"luminosity", "currency", "information", "angle", "solid_angle", "counting"}
 
-	if vector, err := q.unitSignatureVector(); err != nil {
-		return q.signature, err
-	} else {
-		for i, _ := range signatureTypes {
-			vector[i] *= int(math.Pow(20, float64(i)))
+// Returns the dimension as a product of base quantities, e.g. mass·length²·time⁻³ for W; 1 if there are none
+func (d Dimension) Formula() string {
+	var factors []string
+	for i, e := range d.exponents() {
+		if *e == 1 {
+			factors = append(factors, dimensionNames[i])
+		} else if *e != 0 {
+			factors = append(factors, dimensionNames[i]+toSuperscript(strconv.Itoa(*e)))
 		}
+	}
+	if len(factors) == 0 {
+		return "1"
+	}
+	return strings.Join(factors, "·")
+}
 
-		result := reduce(vector, func(prev, curr int) int {
-			return prev + curr
-		}, 0)
-		return result, nil
+// Returns the formula of the dimension of the quantity, e.g. length·time⁻¹ for 5 km/h
+func (q Qty) DimensionFormula() string {
+	return q.Dimension().Formula()
+}
+
+// Returns the formula of the dimension of the unit, e.g. length·time⁻¹ for km/h
+func (u Unit) DimensionFormula() string {
+	return u.Dimension().Formula()
+}
+
+// returns pointers to the exponents, in the order of the fields
+func (d *Dimension) exponents() []*int {
+	return []*int{&d.Length, &d.Mass, &d.Time, &d.Current, &d.Temperature, &d.Substance,
+		&d.Luminosity, &d.Currency, &d.Information, &d.Angle, &d.SolidAngle, &d.Count}
+}
+
+// returns d + o * n
+func (d Dimension) add(o Dimension, n int) Dimension {
+	exps := o.exponents()
+	for i, e := range d.exponents() {
+		*e += *exps[i] * n
 	}
+	return d
 }
 
-// calculates the unit signature vector used by unit_signature
-func (q *Qty) unitSignatureVector() ([]int, error) {
-	if !q.IsBase() {
-		if b, err := q.ToBase(); err != nil {
-			return []int{}, err
-		} else {
-			return b.unitSignatureVector()
-		}
+// Returns the dimension of the quantity, e.g. {Length: 1, Time: -1} for 5 km/h
+func (q Qty) Dimension() Dimension {
+	if q.expr == nil {
+		return Dimension{}
 	}
+	return q.expr.dimension
+}
+
+// Returns the dimension of the unit, e.g. {Length: 1, Time: -1} for km/h
+func (u Unit) Dimension() Dimension {
+	return Qty{expr: u.expr}.Dimension()
+}
 
-	result := make([]int, len(signatureTypes))
-	for i, _ := range result {
-		result[i] = 0
+// calculates the dimension of terms from the dimensions of their units, which are computed once for every unit
+func termsDimension(num, den []term) Dimension {
+	var result Dimension
+	for _, t := range num {
+		result = result.add(unitInfos[t.unit].dimension, t.power)
 	}
-	for _, v := range q.numerator {
-		if r, ok := units[v]; ok {
-			if n := slices.Index(signatureTypes, r.kind); n >= 0 {
-				result[n] = result[n] + 1
-			}
-		}
+	for _, t := range den {
+		result = result.add(unitInfos[t.unit].dimension, -t.power)
+	}
+	return result
+}
+
+// calculates the dimension of unit tokens
+// units that are not base units are expanded using their definitions
+func unitDimension(numerator, denominator []string) Dimension {
+	var result Dimension
+	for _, v := range numerator {
+		result = addDimensionTerm(result, v, 1)
 	}
-	for _, v := range q.denominator {
-		if r, ok := units[v]; ok {
-			if n := slices.Index(signatureTypes, r.kind); n >= 0 {
-				result[n] = result[n] - 1
+	for _, v := range denominator {
+		result = addDimensionTerm(result, v, -1)
+	}
+	return result
+}
+
+// adds the dimension of a unit to result
+// affine base units (eg <temp-K>) have the same dimension as their difference unit (eg <kelvin>)
+// and logarithmic levels (eg <decibel-milliwatt>) have the same dimension as their reference
+func addDimensionTerm(result Dimension, unit string, direction int) Dimension {
+	if a, ok := affineUnits[unit]; ok {
+		return addDimensionTerm(result, a.difference, direction)
+	} else if l, ok := levels[unit]; ok {
+		for _, v := range l.numerator {
+			result = addDimensionTerm(result, v, direction)
+		}
+		for _, v := range l.denominator {
+			result = addDimensionTerm(result, v, -direction)
+		}
+	} else if r, ok := units[unit]; ok {
+		if d, ok := baseDimensions[r.kind]; ok {
+			result = result.add(d, direction)
+		} else if !slices.Contains(baseUnits, unit) {
+			for _, v := range r.numerator {
+				result = addDimensionTerm(result, v, direction)
+			}
+			for _, v := range r.denominator {
+				result = addDimensionTerm(result, v, -direction)
 			}
 		}
 	}
-	return result, nil
+	return result
+}
+
+// calculates the legacy unit signature id, which packs the exponents of the first ten base quantities into base 20 digits.
+// It is derived from the dimension, which should be used to compare units since exponents of ±10 or more collide.
+// The signature is based on the following publication
+
+// Novak, G.S., Jr. "Conversion of units of measurement", IEEE Transactions on Software Engineering,
+// 21(8), Aug 1995, pp.651-661
+// doi://10.1109/32.403789
+// http://ieeexplore.ieee.org/Xplore/login.jsp?url=/iel1/32/9079/00403789.pdf?isnumber=9079&prod=JNL&arnumber=403789&arSt=651&ared=661&arAuthor=Novak%2C+G.S.%2C+Jr.
+func (d Dimension) signature() int {
+	vector := []int{d.Length, d.Time, d.Temperature, d.Mass, d.Current, d.Substance, d.Luminosity, d.Currency, d.Information, d.Angle}
+	result, place := 0, 1
+	for _, v := range vector {
+		result += v * place
+		place *= 20
+	}
+	return result
 }
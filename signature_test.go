@@ -0,0 +1,70 @@
+package goqty
+
+import (
+	"testing"
+)
+
+func TestDimension(t *testing.T) {
+	tests := map[string]struct {
+		units     string
+		expected  Dimension
+		signature int
+		kind      string
+	}{
+		"unitless":  {"", Dimension{}, 0, "unitless"},
+		"length":    {"km", Dimension{Length: 1}, 1, "length"},
+		"force":     {"N", Dimension{Length: 1, Mass: 1, Time: -2}, 7961, "force"},
+		"affine":    {"tempC", Dimension{Temperature: 1}, 400, "temperature"},
+		"level":     {"dBm", Dimension{Length: 2, Mass: 1, Time: -3}, 7942, "power"},
+		"count":     {"doz", Dimension{Count: 1}, 0, "counting"},
+		"steradian": {"sr", Dimension{SolidAngle: 1}, 0, "solid_angle"},
+		"candela":   {"cd", Dimension{Luminosity: 1}, 64000000, "luminosity"},
+		"lumen":     {"lm", Dimension{Luminosity: 1, SolidAngle: 1}, 64000000, "luminous_power"},
+		"lux":       {"lux", Dimension{Length: -2, Luminosity: 1, SolidAngle: 1}, 63999998, "illuminance"},
+		"decibel":   {"dB", Dimension{}, 0, "unitless"},
+		"large":     {"m^20", Dimension{Length: 20}, 20, "length²⁰"},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := ParseUnit(test.units)
+			if err != nil {
+				t.Fatalf("failed to parse, got %v", err)
+			}
+			if u.Dimension() != test.expected {
+				t.Errorf("expected %+v, got %+v", test.expected, u.Dimension())
+			}
+			if s := u.Dimension().signature(); s != test.signature {
+				t.Errorf("expected signature %v, got %v", test.signature, s)
+			}
+			if u.Kind() != test.kind {
+				t.Errorf("expected kind %v, got %v", test.kind, u.Kind())
+			}
+		})
+	}
+}
+
+func TestDimensionCompatibility(t *testing.T) {
+	tests := map[string]struct {
+		a, b       string
+		compatible bool
+	}{
+		"same":                {"km/h", "m/s", true},
+		"signature collision": {"m^20", "s", false},
+		"count":               {"each", "", false},
+		"dozen":               {"doz", "each", true},
+		"solid angle":         {"sr", "", false},
+		"luminous power":      {"lm", "cd", false},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, errA := ParseUnit(test.a)
+			b, errB := ParseUnit(test.b)
+			if errA != nil || errB != nil {
+				t.Fatalf("failed to parse, got %v %v", errA, errB)
+			}
+			if a.IsCompatible(b) != test.compatible {
+				t.Errorf("expected %v compatible with %v to be %v", test.a, test.b, test.compatible)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package goqty
+
+import (
+	"cmp"
+	"encoding/binary"
+	"slices"
+)
+
+// unitID is the interned index of a unit or prefix name, e.g. <meter> or <kilo>.
+// Quantities keep their units as terms of unitIDs so that arithmetic doesn't look up and join strings.
+type unitID int32
+
+// noUnit is the unitID of <1>, which is also used for a term without a prefix
+const noUnit unitID = 0
+
+type unitInfo struct {
+	name      string
+	prefix    bool
+	scalar    float64
+	affine    bool
+	level     bool
+	base      bool
+	dimension Dimension
+	// the definition of a unit in other terms, e.g. N => kg*m/s^2
+	numerator   []term
+	denominator []term
+}
+
+var unitInfos, unitIDs = makeUnitIDs()
+
+// assigns a unitID to every prefix and unit, with <1> as noUnit
+func makeUnitIDs() ([]unitInfo, map[string]unitID) {
+	var names []string
+	for name := range prefixes {
+		names = append(names, name)
+	}
+	for name := range units {
+		if name != unity {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	names = slices.Insert(names, 0, unity)
+
+	ids := make(map[string]unitID, len(names))
+	for i, name := range names {
+		ids[name] = unitID(i)
+	}
+	infos := make([]unitInfo, len(names))
+	for i, name := range names {
+		info := unitInfo{name: name, scalar: 1}
+		if p, ok := prefixes[name]; ok {
+			info.prefix = true
+			info.scalar = p.scalar
+		} else {
+			u := units[name]
+			info.scalar = u.scalar
+			info.numerator = termsOf(u.numerator, ids)
+			info.denominator = termsOf(u.denominator, ids)
+			_, info.affine = affineUnits[name]
+			_, info.level = levels[name]
+			info.base = slices.Contains(baseUnits, name)
+			info.dimension = unitDimension([]string{name}, nil)
+		}
+		infos[i] = info
+	}
+	return infos, ids
+}
+
+// a term is a unit with an optional prefix raised to a positive power, e.g. km^2 is {<kilo>, <meter>, 2}
+type term struct {
+	prefix unitID // noUnit if there is no prefix
+	unit   unitID
+	power  int
+}
+
+// converts unit tokens like <kilo>,<meter>,<second> into terms, combining repeated terms,
+// e.g. <meter>,<second>,<meter> => m^2*s; unity and unknown tokens are dropped
+func termsOf(tokens []string, ids map[string]unitID) []term {
+	var result []term
+	for i := 0; i < len(tokens); i++ {
+		t := term{power: 1}
+		if _, ok := prefixes[tokens[i]]; ok && i+1 < len(tokens) {
+			t.prefix = ids[tokens[i]]
+			i++
+		}
+		if id, ok := ids[tokens[i]]; !ok || id == noUnit {
+			continue
+		} else {
+			t.unit = id
+		}
+		if j := slices.IndexFunc(result, t.same); j >= 0 {
+			result[j].power++
+		} else {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// returns true if two terms have the same prefix and unit, regardless of their powers
+func (t term) same(other term) bool {
+	return t.prefix == other.prefix && t.unit == other.unit
+}
+
+// converts terms back into unit tokens, e.g. km^2 => <kilo>,<meter>,<kilo>,<meter>
+// no terms is unity
+func tokensOf(terms []term) []string {
+	if len(terms) == 0 {
+		return []string{unity}
+	}
+	var result []string
+	for _, t := range terms {
+		token := []string{unitInfos[t.unit].name}
+		if t.prefix != noUnit {
+			token = []string{unitInfos[t.prefix].name, unitInfos[t.unit].name}
+		}
+		result = append(result, slices.Repeat(token, t.power)...)
+	}
+	return result
+}
+
+// multiplies terms, combining repeated terms, e.g. m*s times m => m^2*s
+func addTerms(terms, other []term) []term {
+	result := slices.Clone(terms)
+	for _, t := range other {
+		if i := slices.IndexFunc(result, t.same); i >= 0 {
+			result[i].power += t.power
+		} else {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// raises terms to a non-negative power, e.g. (m*s)^2 => m^2*s^2
+func powTerms(terms []term, n int) []term {
+	if n == 0 {
+		return nil
+	}
+	result := make([]term, len(terms))
+	for i, t := range terms {
+		result[i] = term{t.prefix, t.unit, t.power * n}
+	}
+	return result
+}
+
+// takes a positive root of terms, e.g. root 2 of m^2*s^4 => m*s^2
+// returns false if any power isn't a multiple of n
+func rootTerms(terms []term, n int) ([]term, bool) {
+	result := make([]term, len(terms))
+	for i, t := range terms {
+		if t.power%n != 0 {
+			return nil, false
+		}
+		result[i] = term{t.prefix, t.unit, t.power / n}
+	}
+	return result, true
+}
+
+// returns a copy of the terms sorted by unit, then prefix
+func sortedTerms(terms []term) []term {
+	return slices.SortedFunc(slices.Values(terms), func(a, b term) int {
+		return cmp.Or(cmp.Compare(a.unit, b.unit), cmp.Compare(a.prefix, b.prefix))
+	})
+}
+
+// appends a compact binary key for terms, used to intern unit expressions
+func appendTermsKey(key []byte, terms []term) []byte {
+	for _, t := range terms {
+		key = binary.AppendUvarint(key, uint64(t.prefix))
+		key = binary.AppendUvarint(key, uint64(t.unit))
+		key = binary.AppendUvarint(key, uint64(t.power))
+	}
+	return key
+}
+
+// the largest power of a unit in an expression, which keeps repeated powers from overflowing
+const maxPower = 1 << 20
+
+func largePower(t term) bool {
+	return t.power > maxPower
+}
@@ -0,0 +1,59 @@
+package goqty
+
+// aliasTrie maps unit or prefix aliases to their names, e.g. km => <kilometer>.
+// Walking the runes of an atom once replaces matching it against an alternation of every alias.
+type aliasTrie struct {
+	children map[rune]*aliasTrie
+	name     string // set if an alias ends at this node
+}
+
+func newAliasTrie(aliases map[string]string) *aliasTrie {
+	root := &aliasTrie{}
+	for alias, name := range aliases {
+		node := root
+		for _, r := range alias {
+			child, ok := node.children[r]
+			if !ok {
+				if node.children == nil {
+					node.children = make(map[rune]*aliasTrie)
+				}
+				child = &aliasTrie{}
+				node.children[r] = child
+			}
+			node = child
+		}
+		node.name = name
+	}
+	return root
+}
+
+// returns the name of the alias that is exactly s
+func (t *aliasTrie) lookup(s string) (string, bool) {
+	node := t
+	for _, r := range s {
+		if node = node.children[r]; node == nil {
+			return "", false
+		}
+	}
+	return node.name, node.name != ""
+}
+
+type aliasMatch struct {
+	end  int // the byte offset in s where the alias ends
+	name string
+}
+
+// returns every alias that s starts with, shortest first
+func (t *aliasTrie) prefixesOf(s string) []aliasMatch {
+	var result []aliasMatch
+	node := t
+	for i, r := range s {
+		if node = node.children[r]; node == nil {
+			break
+		}
+		if node.name != "" {
+			result = append(result, aliasMatch{i + len(string(r)), node.name})
+		}
+	}
+	return result
+}
@@ -0,0 +1,301 @@
+package goqty
+
+import (
+	"fmt"
+)
+
+// A QuantityKind is a phantom type that names one of the well-known kinds of quantities, e.g. Length.
+// It is used as the type parameter of Quantity so that quantities of different kinds can't be mixed up at compile time.
+type QuantityKind interface {
+	KindName() string
+}
+
+type Unitless struct{}
+type Length struct{}
+type Area struct{}
+type Volume struct{}
+type Mass struct{}
+type Time struct{}
+type Speed struct{}
+type Acceleration struct{}
+type Force struct{}
+type Energy struct{}
+type Power struct{}
+type Pressure struct{}
+type Density struct{}
+type Frequency struct{}
+type Current struct{}
+type Charge struct{}
+type Potential struct{}
+type Resistance struct{}
+type Temperature struct{}
+type Angle struct{}
+type AngularVelocity struct{}
+type VolumetricFlow struct{}
+type Information struct{}
+type InformationRate struct{}
+
+func (Unitless) KindName() string        { return "unitless" }
+func (Length) KindName() string          { return "length" }
+func (Area) KindName() string            { return "area" }
+func (Volume) KindName() string          { return "volume" }
+func (Mass) KindName() string            { return "mass" }
+func (Time) KindName() string            { return "time" }
+func (Speed) KindName() string           { return "speed" }
+func (Acceleration) KindName() string    { return "acceleration" }
+func (Force) KindName() string           { return "force" }
+func (Energy) KindName() string          { return "energy" }
+func (Power) KindName() string           { return "power" }
+func (Pressure) KindName() string        { return "pressure" }
+func (Density) KindName() string         { return "density" }
+func (Frequency) KindName() string       { return "frequency" }
+func (Current) KindName() string         { return "current" }
+func (Charge) KindName() string          { return "charge" }
+func (Potential) KindName() string       { return "potential" }
+func (Resistance) KindName() string      { return "resistance" }
+func (Temperature) KindName() string     { return "temperature" }
+func (Angle) KindName() string           { return "angle" }
+func (AngularVelocity) KindName() string { return "angular_velocity" }
+func (VolumetricFlow) KindName() string  { return "volumetric_flow" }
+func (Information) KindName() string     { return "information" }
+func (InformationRate) KindName() string { return "information_rate" }
+
+// Quantity is a Qty that is statically known to be of kind K, e.g. Quantity[Length].
+// Quantities are created with NewQuantity, ParseQuantity or AsQuantity.
+// The zero value is 0 in the SI unit of the kind, e.g. 0 m, so it can be used to start a sum.
+// Use Qty() to get back the underlying *Qty.
+type Quantity[K QuantityKind] struct {
+	qty Qty
+}
+
+// Creates a quantity of kind K, e.g. NewQuantity[Length](1.5, "m")
+func NewQuantity[K QuantityKind](scalar float64, units string) (Quantity[K], error) {
+	if q, err := New(scalar, units); err != nil {
+		return Quantity[K]{}, err
+	} else {
+		return AsQuantity[K](q)
+	}
+}
+
+// Parses a quantity of kind K, e.g. ParseQuantity[Speed]("50 km/h")
+func ParseQuantity[K QuantityKind](expr string) (Quantity[K], error) {
+	if q, err := Parse(expr); err != nil {
+		return Quantity[K]{}, err
+	} else {
+		return AsQuantity[K](q)
+	}
+}
+
+// Returns q as a quantity of kind K, or an error if q is of a different kind
+func AsQuantity[K QuantityKind](q *Qty) (Quantity[K], error) {
+	var k K
+	if kind := q.Kind(); kind != k.KindName() {
+		return Quantity[K]{}, fmt.Errorf("expected %v, got %v", k.KindName(), kind)
+	}
+	return Quantity[K]{*q}, nil
+}
+
+// returns the quantity, or 0 in the SI unit of the kind for the zero value
+func (x Quantity[K]) value() *Qty {
+	var k K
+	if x.qty == (Qty{}) {
+		if u, ok := kindUnit(k.KindName()); ok {
+			return &Qty{expr: u.expr}
+		}
+	}
+	return &x.qty
+}
+
+// Returns the underlying dynamically typed quantity
+func (x Quantity[K]) Qty() *Qty {
+	return x.value()
+}
+
+func (x Quantity[K]) Scalar() float64 {
+	return x.value().Scalar()
+}
+
+func (x Quantity[K]) Units() string {
+	return x.value().Units()
+}
+
+func (x Quantity[K]) String() string {
+	return x.value().String()
+}
+
+// Converts to other units of the same kind
+func (x Quantity[K]) To(units string) (Quantity[K], error) {
+	if q, err := x.value().To(units); err != nil {
+		return Quantity[K]{}, err
+	} else {
+		return AsQuantity[K](q)
+	}
+}
+
+func (x Quantity[K]) Add(y Quantity[K]) (Quantity[K], error) {
+	if q, err := x.value().Add(y.value()); err != nil {
+		return Quantity[K]{}, err
+	} else {
+		return AsQuantity[K](q)
+	}
+}
+
+func (x Quantity[K]) Sub(y Quantity[K]) (Quantity[K], error) {
+	if q, err := x.value().Sub(y.value()); err != nil {
+		return Quantity[K]{}, err
+	} else {
+		return AsQuantity[K](q)
+	}
+}
+
+// Multiplies by a number, the kind is unchanged
+func (x Quantity[K]) Mul(f float64) (Quantity[K], error) {
+	if q, err := x.value().Mul(f); err != nil {
+		return Quantity[K]{}, err
+	} else {
+		return AsQuantity[K](q)
+	}
+}
+
+// Divides by a number, the kind is unchanged
+func (x Quantity[K]) Div(f float64) (Quantity[K], error) {
+	if q, err := x.value().Div(f); err != nil {
+		return Quantity[K]{}, err
+	} else {
+		return AsQuantity[K](q)
+	}
+}
+
+func (x Quantity[K]) CompareTo(y Quantity[K]) (int, error) {
+	return x.value().CompareTo(y.value())
+}
+
+func (x Quantity[K]) Eq(y Quantity[K]) (bool, error) {
+	return x.value().Eq(y.value())
+}
+
+func (x Quantity[K]) Lt(y Quantity[K]) (bool, error) {
+	return x.value().Lt(y.value())
+}
+
+func (x Quantity[K]) Gt(y Quantity[K]) (bool, error) {
+	return x.value().Gt(y.value())
+}
+
+// Multiplies two quantities and checks at runtime that the result is of kind R,
+// e.g. MulAs[Energy](force, distance).
+// Prefer the statically typed functions such as MulForceLength where they exist.
+func MulAs[R, A, B QuantityKind](a Quantity[A], b Quantity[B]) (Quantity[R], error) {
+	if q, err := a.value().Mul(b.value()); err != nil {
+		return Quantity[R]{}, err
+	} else {
+		return AsQuantity[R](q)
+	}
+}
+
+// Divides two quantities and checks at runtime that the result is of kind R,
+// e.g. DivAs[Speed](distance, duration).
+// Prefer the statically typed functions such as DivLengthTime where they exist.
+func DivAs[R, A, B QuantityKind](a Quantity[A], b Quantity[B]) (Quantity[R], error) {
+	if q, err := a.value().Div(b.value()); err != nil {
+		return Quantity[R]{}, err
+	} else {
+		return AsQuantity[R](q)
+	}
+}
+
+// products of common kinds
+
+func MulLengthLength(a, b Quantity[Length]) (Quantity[Area], error) {
+	return MulAs[Area](a, b)
+}
+func MulAreaLength(a Quantity[Area], b Quantity[Length]) (Quantity[Volume], error) {
+	return MulAs[Volume](a, b)
+}
+func MulSpeedTime(a Quantity[Speed], b Quantity[Time]) (Quantity[Length], error) {
+	return MulAs[Length](a, b)
+}
+func MulAccelerationTime(a Quantity[Acceleration], b Quantity[Time]) (Quantity[Speed], error) {
+	return MulAs[Speed](a, b)
+}
+func MulMassAcceleration(a Quantity[Mass], b Quantity[Acceleration]) (Quantity[Force], error) {
+	return MulAs[Force](a, b)
+}
+func MulForceLength(a Quantity[Force], b Quantity[Length]) (Quantity[Energy], error) {
+	return MulAs[Energy](a, b)
+}
+func MulPressureArea(a Quantity[Pressure], b Quantity[Area]) (Quantity[Force], error) {
+	return MulAs[Force](a, b)
+}
+func MulPowerTime(a Quantity[Power], b Quantity[Time]) (Quantity[Energy], error) {
+	return MulAs[Energy](a, b)
+}
+func MulDensityVolume(a Quantity[Density], b Quantity[Volume]) (Quantity[Mass], error) {
+	return MulAs[Mass](a, b)
+}
+func MulPotentialCurrent(a Quantity[Potential], b Quantity[Current]) (Quantity[Power], error) {
+	return MulAs[Power](a, b)
+}
+func MulCurrentResistance(a Quantity[Current], b Quantity[Resistance]) (Quantity[Potential], error) {
+	return MulAs[Potential](a, b)
+}
+func MulCurrentTime(a Quantity[Current], b Quantity[Time]) (Quantity[Charge], error) {
+	return MulAs[Charge](a, b)
+}
+
+// quotients of common kinds
+
+func DivAreaLength(a Quantity[Area], b Quantity[Length]) (Quantity[Length], error) {
+	return DivAs[Length](a, b)
+}
+func DivVolumeArea(a Quantity[Volume], b Quantity[Area]) (Quantity[Length], error) {
+	return DivAs[Length](a, b)
+}
+func DivVolumeLength(a Quantity[Volume], b Quantity[Length]) (Quantity[Area], error) {
+	return DivAs[Area](a, b)
+}
+func DivLengthTime(a Quantity[Length], b Quantity[Time]) (Quantity[Speed], error) {
+	return DivAs[Speed](a, b)
+}
+func DivLengthSpeed(a Quantity[Length], b Quantity[Speed]) (Quantity[Time], error) {
+	return DivAs[Time](a, b)
+}
+func DivSpeedTime(a Quantity[Speed], b Quantity[Time]) (Quantity[Acceleration], error) {
+	return DivAs[Acceleration](a, b)
+}
+func DivForceMass(a Quantity[Force], b Quantity[Mass]) (Quantity[Acceleration], error) {
+	return DivAs[Acceleration](a, b)
+}
+func DivForceArea(a Quantity[Force], b Quantity[Area]) (Quantity[Pressure], error) {
+	return DivAs[Pressure](a, b)
+}
+func DivEnergyTime(a Quantity[Energy], b Quantity[Time]) (Quantity[Power], error) {
+	return DivAs[Power](a, b)
+}
+func DivEnergyPower(a Quantity[Energy], b Quantity[Power]) (Quantity[Time], error) {
+	return DivAs[Time](a, b)
+}
+func DivMassVolume(a Quantity[Mass], b Quantity[Volume]) (Quantity[Density], error) {
+	return DivAs[Density](a, b)
+}
+func DivVolumeTime(a Quantity[Volume], b Quantity[Time]) (Quantity[VolumetricFlow], error) {
+	return DivAs[VolumetricFlow](a, b)
+}
+func DivPotentialCurrent(a Quantity[Potential], b Quantity[Current]) (Quantity[Resistance], error) {
+	return DivAs[Resistance](a, b)
+}
+func DivPotentialResistance(a Quantity[Potential], b Quantity[Resistance]) (Quantity[Current], error) {
+	return DivAs[Current](a, b)
+}
+func DivPowerPotential(a Quantity[Power], b Quantity[Potential]) (Quantity[Current], error) {
+	return DivAs[Current](a, b)
+}
+func DivChargeTime(a Quantity[Charge], b Quantity[Time]) (Quantity[Current], error) {
+	return DivAs[Current](a, b)
+}
+func DivAngleTime(a Quantity[Angle], b Quantity[Time]) (Quantity[AngularVelocity], error) {
+	return DivAs[AngularVelocity](a, b)
+}
+func DivInformationTime(a Quantity[Information], b Quantity[Time]) (Quantity[InformationRate], error) {
+	return DivAs[InformationRate](a, b)
+}
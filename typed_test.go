@@ -0,0 +1,162 @@
+package goqty
+
+import (
+	"testing"
+)
+
+func TestQuantity(t *testing.T) {
+	a, err := ParseQuantity[Length]("2.5 m")
+	if err != nil {
+		t.Fatalf("failed to parse, got %v", err)
+	}
+	b, err := NewQuantity[Length](30, "cm")
+	if err != nil {
+		t.Fatalf("failed to create, got %v", err)
+	}
+	if sum, err := a.Add(b); err != nil {
+		t.Errorf("failed to add, got %v", err)
+	} else if sum.String() != "2.8 m" {
+		t.Errorf("expected 2.8 m, got %v", sum)
+	}
+	if diff, err := a.Sub(b); err != nil {
+		t.Errorf("failed to subtract, got %v", err)
+	} else if diff.String() != "2.2 m" {
+		t.Errorf("expected 2.2 m, got %v", diff)
+	}
+	if c, err := a.To("cm"); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else if c.String() != "250 cm" {
+		t.Errorf("expected 250 cm, got %v", c)
+	}
+	if _, err := a.To("s"); err == nil {
+		t.Errorf("expected error converting a length to seconds")
+	}
+	if m, err := a.Mul(2); err != nil {
+		t.Errorf("failed to multiply, got %v", err)
+	} else if m.String() != "5 m" {
+		t.Errorf("expected 5 m, got %v", m)
+	}
+	if gt, err := a.Gt(b); err != nil {
+		t.Errorf("failed to compare, got %v", err)
+	} else if !gt {
+		t.Errorf("expected %v > %v", a, b)
+	}
+	if a.Qty().Kind() != "length" {
+		t.Errorf("expected underlying kind length, got %v", a.Qty().Kind())
+	}
+}
+
+func TestQuantityZeroValue(t *testing.T) {
+	var total Quantity[Length]
+	if total.String() != "0 m" || total.Scalar() != 0 || total.Units() != "m" {
+		t.Errorf("expected 0 m, got %v", total)
+	}
+	for _, expr := range []string{"2 m", "50 cm"} {
+		d, _ := ParseQuantity[Length](expr)
+		var err error
+		if total, err = total.Add(d); err != nil {
+			t.Fatalf("failed to add, got %v", err)
+		}
+	}
+	if total.String() != "2.5 m" {
+		t.Errorf("expected 2.5 m, got %v", total)
+	}
+
+	var speed Quantity[Speed]
+	if lt, err := speed.Lt(Quantity[Speed]{}); err != nil || lt {
+		t.Errorf("expected 0 m/s not less than itself, got %v %v", lt, err)
+	}
+	if _, err := speed.To("km/h"); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	}
+	if _, err := MulSpeedTime(speed, Quantity[Time]{}); err != nil {
+		t.Errorf("failed to multiply, got %v", err)
+	}
+
+	// the quantity returned with an error is usable too
+	if q, err := ParseQuantity[Length]("5 kg"); err == nil {
+		t.Errorf("expected error")
+	} else if q.String() != "0 m" {
+		t.Errorf("expected 0 m, got %v", q)
+	}
+}
+
+func TestQuantityKindMismatch(t *testing.T) {
+	tests := map[string]struct {
+		expr     string
+		fn       func(string) error
+		expected string
+	}{
+		"mass as length": {"5 kg", func(s string) error { _, err := ParseQuantity[Length](s); return err }, "expected length, got mass"},
+		"length as time": {"5 m", func(s string) error { _, err := ParseQuantity[Time](s); return err }, "expected time, got length"},
+		"speed as speed": {"5 m/s", func(s string) error { _, err := ParseQuantity[Speed](s); return err }, ""},
+		"tempC as temp":  {"5 tempC", func(s string) error { _, err := ParseQuantity[Temperature](s); return err }, ""},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := test.fn(test.expr); err != nil {
+				if err.Error() != test.expected {
+					t.Errorf("expected %v, got %v", test.expected, err)
+				}
+			} else if test.expected != "" {
+				t.Errorf("expected error %v", test.expected)
+			}
+		})
+	}
+}
+
+func TestQuantityProducts(t *testing.T) {
+	length, _ := ParseQuantity[Length]("100 m")
+	width, _ := ParseQuantity[Length]("20 m")
+	duration, _ := ParseQuantity[Time]("10 s")
+	mass, _ := ParseQuantity[Mass]("2 kg")
+	voltage, _ := ParseQuantity[Potential]("12 V")
+	current, _ := ParseQuantity[Current]("2 A")
+
+	if area, err := MulLengthLength(length, width); err != nil {
+		t.Errorf("failed to multiply, got %v", err)
+	} else if area.String() != "2000 m^2" {
+		t.Errorf("expected 2000 m^2, got %v", area)
+	}
+	speed, err := DivLengthTime(length, duration)
+	if err != nil {
+		t.Errorf("failed to divide, got %v", err)
+	} else if speed.String() != "10 m/s" {
+		t.Errorf("expected 10 m/s, got %v", speed)
+	}
+	acceleration, err := DivSpeedTime(speed, duration)
+	if err != nil {
+		t.Errorf("failed to divide, got %v", err)
+	} else if acceleration.String() != "1 m/s^2" {
+		t.Errorf("expected 1 m/s^2, got %v", acceleration)
+	}
+	if force, err := MulMassAcceleration(mass, acceleration); err != nil {
+		t.Errorf("failed to multiply, got %v", err)
+	} else if force.Qty().Kind() != "force" {
+		t.Errorf("expected force, got %v", force.Qty().Kind())
+	}
+	if power, err := MulPotentialCurrent(voltage, current); err != nil {
+		t.Errorf("failed to multiply, got %v", err)
+	} else if w, err := power.To("W"); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else if w.String() != "24 W" {
+		t.Errorf("expected 24 W, got %v", w)
+	}
+	if resistance, err := DivPotentialCurrent(voltage, current); err != nil {
+		t.Errorf("failed to divide, got %v", err)
+	} else if r, err := resistance.To("ohm"); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else if r.String() != "6 Ω" {
+		t.Errorf("expected 6 Ω, got %v", r)
+	}
+	if _, err := MulAs[Energy](length, width); err == nil {
+		t.Errorf("expected error multiplying two lengths into an energy")
+	} else if err.Error() != "expected energy, got area" {
+		t.Errorf("expected %v, got %v", "expected energy, got area", err)
+	}
+	if v, err := DivAs[Speed](width, duration); err != nil {
+		t.Errorf("failed to divide, got %v", err)
+	} else if v.String() != "2 m/s" {
+		t.Errorf("expected 2 m/s, got %v", v)
+	}
+}
@@ -0,0 +1,203 @@
+package goqty
+
+//go:generate go run gen_units.go
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Unit is a parsed unit expression without a scalar, e.g. kg*m/s^2.
+// Parse units once with ParseUnit and pass the Unit to To, New, ToPrec or SwiftConverter
+// so that hot paths don't parse the same units over and over.
+//
+// Unit is a value type like Qty.  The zero value is unitless and units can be compared with ==.
+type Unit struct {
+	expr *unitExpr // nil when unitless
+}
+
+// Parses a unit expression, e.g. "m/s^2".  An empty string is unitless.
+func ParseUnit(units string) (Unit, error) {
+	if strings.TrimSpace(units) == "" {
+		return Unit{}, nil
+	}
+	if q, err := Parse(units); err != nil {
+		return Unit{}, err
+	} else if q.scalar != 1 {
+		return Unit{}, fmt.Errorf("expected units without a scalar, got %v", units)
+	} else {
+		return q.Unit(), nil
+	}
+}
+
+// Returns the units of the quantity
+func (q Qty) Unit() Unit {
+	return Unit{q.expr}
+}
+
+// returns a Unit given a string or Unit
+func toUnit(units interface{}) (Unit, error) {
+	switch t := units.(type) {
+	case Unit:
+		return t, nil
+	case string:
+		return ParseUnit(t)
+	default:
+		return Unit{}, fmt.Errorf("expecting string or Unit, got %T", t)
+	}
+}
+
+func newUnit(num, den []term) (Unit, error) {
+	if q, err := newQtyTerms(1, num, den); err != nil {
+		return Unit{}, err
+	} else {
+		return q.Unit(), nil
+	}
+}
+
+// returns the Unit for a unit name, e.g. <meter>, for the generated unit variables
+func mustUnit(name string) Unit {
+	if u, err := newUnit([]term{{noUnit, unitIDs[name], 1}}, nil); err != nil {
+		panic(err)
+	} else {
+		return u
+	}
+}
+
+// applies a prefix to a unit for the generated prefix functions, e.g. Kilo(Gram)
+// Like regexp.MustCompile this panics, since it is meant to be used with the generated unit variables;
+// u must be a single unit without a prefix.
+func mustPrefix(prefix string, u Unit) Unit {
+	num := u.numTerms()
+	if len(num) != 1 || num[0].prefix != noUnit || num[0].power != 1 || len(u.denTerms()) > 0 {
+		panic(fmt.Sprintf("cannot apply %v to %v", prefix, u))
+	}
+	if p, err := newUnit([]term{{unitIDs[prefix], num[0].unit, 1}}, nil); err != nil {
+		panic(err)
+	} else {
+		return p
+	}
+}
+
+func (u Unit) numTerms() []term {
+	return Qty{expr: u.expr}.numTerms()
+}
+
+func (u Unit) denTerms() []term {
+	return Qty{expr: u.expr}.denTerms()
+}
+
+func (u Unit) String() string {
+	return Qty{expr: u.expr}.Units()
+}
+
+func (u Unit) Numerator() []string {
+	return Qty{expr: u.expr}.Numerator()
+}
+
+func (u Unit) Denominator() []string {
+	return Qty{expr: u.expr}.Denominator()
+}
+
+func (u Unit) IsUnitless() bool {
+	return u.expr == nil
+}
+
+func (u Unit) IsCompatible(other Unit) bool {
+	return u.Dimension() == other.Dimension()
+}
+
+func (u Unit) Kind() string {
+	return Qty{expr: u.expr}.Kind()
+}
+
+// Returns the canonical name of a single unit, e.g. <meter> for Meter,
+// or "" for a unit with a prefix or power or a compound unit, e.g. km, m^2 or m/s
+func (u Unit) Name() string {
+	return Qty{expr: u.expr}.singleUnit()
+}
+
+// Returns the aliases of a single unit that Parse accepts, e.g. m, meter, meters, metre, metres for Meter,
+// or nil for a unit without a Name
+func (u Unit) Aliases() []string {
+	return slices.Clone(UnitAliases(u.Name()))
+}
+
+// returns a quantity of one of the unit without going through the parser
+func (u Unit) one() *Qty {
+	q := Qty{scalar: 1, expr: u.expr}
+	// the units were validated when they were interned, so this can't fail
+	q.baseScalar, _ = q.computeBaseScalar()
+	return &q
+}
+
+// Multiplies two units, e.g. kg * m/s^2 => kg*m/s^2
+// Only identical terms cancel out, so m * 1/cm stays m/cm; use Qty to simplify compatible units.
+func (u Unit) Mul(other Unit) (Unit, error) {
+	num, den := cancelTerms(
+		addTerms(u.numTerms(), other.numTerms()),
+		addTerms(u.denTerms(), other.denTerms()),
+	)
+	return newUnit(num, den)
+}
+
+// Divides two units, e.g. m / s => m/s
+// Only identical terms cancel out, so m / cm stays m/cm; use Qty to simplify compatible units.
+func (u Unit) Div(other Unit) (Unit, error) {
+	num, den := cancelTerms(
+		addTerms(u.numTerms(), other.denTerms()),
+		addTerms(u.denTerms(), other.numTerms()),
+	)
+	return newUnit(num, den)
+}
+
+// Raises a unit to the integer power n, e.g. m^3
+// The power must not be larger than MaxExponent.
+func (u Unit) Pow(n int) (Unit, error) {
+	if q, err := u.one().Pow(n); err != nil {
+		return Unit{}, err
+	} else {
+		return q.Unit(), nil
+	}
+}
+
+// Returns the size of the unit in base SI units, e.g. 1000 for km.
+// Affine units like tempC and logarithmic levels like dBm can't be converted with a factor.
+func (u Unit) Factor() (float64, error) {
+	one := u.one()
+	if one.IsAffine() || one.IsLevel() {
+		return 0, fmt.Errorf("cannot convert %v with a factor", u)
+	}
+	return one.baseScalar, nil
+}
+
+// Returns the factor that converts values in this unit to the other unit, e.g. 1000 for km to m.
+func (u Unit) FactorTo(other Unit) (float64, error) {
+	if !u.IsCompatible(other) {
+		return 0, fmt.Errorf("incompatible units: %v and %v", u, other)
+	}
+	if from, err := u.Factor(); err != nil {
+		return 0, err
+	} else if to, err := other.Factor(); err != nil {
+		return 0, err
+	} else {
+		return divSafe(from, to)
+	}
+}
+
+// removes the terms that appear in both the numerator and the denominator.
+// unlike cleanTerms, terms with different prefixes are kept apart.
+func cancelTerms(numerator, denominator []term) ([]term, []term) {
+	num := slices.Clone(numerator)
+	den := slices.Clone(denominator)
+	for i := range num {
+		if j := slices.IndexFunc(den, num[i].same); j >= 0 {
+			n := min(num[i].power, den[j].power)
+			num[i].power -= n
+			den[j].power -= n
+		}
+	}
+	isZero := func(t term) bool { return t.power == 0 }
+	return slices.DeleteFunc(num, isZero), slices.DeleteFunc(den, isZero)
+}
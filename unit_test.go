@@ -0,0 +1,205 @@
+package goqty
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseUnit(t *testing.T) {
+	tests := map[string]struct {
+		units    string
+		expected string
+		kind     string
+		err      bool
+	}{
+		"empty":    {"", "", "unitless", false},
+		"m":        {"m", "m", "length", false},
+		"compound": {"kg*m/s^2", "kg*m/s^2", "force", false},
+		"scalar":   {"5 m", "", "", true},
+		"unknown":  {"foo", "", "", true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			u, err := ParseUnit(test.units)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected error, got %v", u)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("failed to parse, got %v", err)
+				return
+			}
+			if u.String() != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, u)
+			}
+			if u.Kind() != test.kind {
+				t.Errorf("expected kind %v, got %v", test.kind, u.Kind())
+			}
+		})
+	}
+}
+
+func TestUnitOperators(t *testing.T) {
+	tests := map[string]struct {
+		op       func(a, b Unit) (Unit, error)
+		a, b     string
+		expected string
+	}{
+		"mul":          {Unit.Mul, "kg", "m/s^2", "kg*m/s^2"},
+		"mul cancel":   {Unit.Mul, "m/s", "s", "m"},
+		"mul prefix":   {Unit.Mul, "m", "1/cm", "m/cm"},
+		"div":          {Unit.Div, "m", "s", "m/s"},
+		"div cancel":   {Unit.Div, "N*m", "m", "N"},
+		"div unitless": {Unit.Div, "m", "m", ""},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			a, _ := ParseUnit(test.a)
+			b, _ := ParseUnit(test.b)
+			if actual, err := test.op(a, b); err != nil {
+				t.Errorf("failed, got %v", err)
+			} else if actual.String() != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestUnitPow(t *testing.T) {
+	m, _ := ParseUnit("m")
+	if cube, err := m.Pow(3); err != nil {
+		t.Errorf("failed, got %v", err)
+	} else if cube.Kind() != "volume" {
+		t.Errorf("expected volume, got %v", cube.Kind())
+	}
+	c, _ := ParseUnit("tempC")
+	if _, err := c.Pow(2); err == nil {
+		t.Errorf("expected error")
+	}
+	if _, err := m.Pow(MaxExponent + 1); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestUnitFactor(t *testing.T) {
+	tests := map[string]struct {
+		from, to string
+		expected float64
+		err      bool
+	}{
+		"km to m":      {"km", "m", 1000, false},
+		"m to km":      {"m", "km", 0.001, false},
+		"in to cm":     {"in", "cm", 2.54, false},
+		"incompatible": {"m", "s", 0, true},
+		"affine":       {"tempC", "tempF", 0, true},
+		"level":        {"dBm", "W", 0, true},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			from, _ := ParseUnit(test.from)
+			to, _ := ParseUnit(test.to)
+			actual, err := from.FactorTo(to)
+			if test.err {
+				if err == nil {
+					t.Errorf("expected error, got %v", actual)
+				}
+			} else if err != nil {
+				t.Errorf("failed, got %v", err)
+			} else if actual != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestUnitArguments(t *testing.T) {
+	m, _ := ParseUnit("m")
+	cm, _ := ParseUnit("cm")
+	meter, _ := ParseUnit("meter")
+
+	q, err := New(1.5, m)
+	if err != nil {
+		t.Errorf("failed to create, got %v", err)
+		return
+	}
+	if q.Unit() != meter {
+		t.Errorf("expected the same units")
+	}
+	if c, err := q.To(cm); err != nil {
+		t.Errorf("failed to convert, got %v", err)
+	} else if c.String() != "150 cm" {
+		t.Errorf("expected 150 cm, got %v", c)
+	}
+
+	x, _ := Parse("6.3782 m")
+	if p, err := x.ToPrec(cm); err != nil {
+		t.Errorf("failed to round, got %v", err)
+	} else if p.String() != "6.38 m" {
+		t.Errorf("expected 6.38 m, got %v", p)
+	}
+
+	if converter, err := SwiftConverter(m, cm); err != nil {
+		t.Errorf("failed to create converter, got %v", err)
+	} else if actual, _ := converter([]float64{1, 2}); actual[0] != 100 || actual[1] != 200 {
+		t.Errorf("expected [100 200], got %v", actual)
+	}
+
+	if _, err := New(1, 5); err == nil {
+		t.Errorf("expected error")
+	}
+}
+
+func TestGeneratedUnits(t *testing.T) {
+	mps, _ := Meter.Div(Second)
+	tests := map[string]struct {
+		unit     Unit
+		expected string
+		kind     string
+		name     string
+		aliases  []string
+	}{
+		"meter":       {Meter, "m", "length", "<meter>", []string{"m", "meter", "meters", "metre", "metres"}},
+		"kilogram":    {Kilogram, "kg", "mass", "<kilogram>", []string{"kg", "kilogram", "kilograms"}},
+		"kilo gram":   {Kilo(Gram), "kg", "mass", "", nil},
+		"milli liter": {Milli(Liter), "ml", "volume", "", nil},
+		"pound force": {PoundForce, "lbf", "force", "<pound-force>", []string{"lbf", "pound-force"}},
+		"temp C":      {TempC, "tempC", "temperature", "<temp-C>", []string{"tempC", "temp-C"}},
+		"enzyme unit": {EnzymeUnit, "U", "activity", "<unit>", []string{"U", "enzUnit", "unit"}},
+		"compound":    {mps, "m/s", "speed", "", nil},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if test.unit.String() != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, test.unit)
+			}
+			if test.unit.Kind() != test.kind {
+				t.Errorf("expected kind %v, got %v", test.kind, test.unit.Kind())
+			}
+			if test.unit.Name() != test.name {
+				t.Errorf("expected name %v, got %v", test.name, test.unit.Name())
+			}
+			if !slices.Equal(test.unit.Aliases(), test.aliases) {
+				t.Errorf("expected aliases %v, got %v", test.aliases, test.unit.Aliases())
+			}
+		})
+	}
+
+	km, _ := ParseUnit("km")
+	if Kilo(Meter) != km {
+		t.Errorf("expected Kilo(Meter) to equal km")
+	}
+	if q, err := New(1, Kilo(Meter)); err != nil {
+		t.Errorf("failed to create, got %v", err)
+	} else if m, err := q.To(Meter); err != nil || m.String() != "1000 m" {
+		t.Errorf("expected 1000 m, got %v (%v)", m, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected a panic when prefixing a prefixed unit")
+		}
+	}()
+	Kilo(Kilo(Gram))
+}
@@ -0,0 +1,13 @@
+// The unitcheck command checks the constant quantities and units passed to goqty.
+//
+//	go vet -vettool=$(which unitcheck) ./...
+package main
+
+import (
+	"github.com/wjanssens/goqty/unitcheck"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(unitcheck.Analyzer)
+}
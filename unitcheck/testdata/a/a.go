@@ -0,0 +1,62 @@
+package a
+
+import (
+	"github.com/wjanssens/goqty"
+)
+
+const speed = "ft/s"
+
+func parse() {
+	goqty.Parse("5 kg")
+	goqty.Parse("5 kgs") // want `invalid quantity "5 kgs": unit not recognized`
+	goqty.ParseUnit("m/s")
+	goqty.ParseUnit("furlongs/fortnite") // want `invalid unit "furlongs/fortnite"`
+	goqty.New(5, "kg")
+	goqty.New(5, "kgs") // want `invalid unit "kgs"`
+
+	var dynamic string
+	goqty.Parse(dynamic)
+}
+
+func convert(x *goqty.Qty) {
+	x.To(speed)
+	x.To("ft/ss") // want `invalid quantity "ft/ss"`
+
+	q, _ := goqty.Parse("5 m")
+	q.To("ft")
+	q.To("s")    // want `incompatible units: m and s`
+	q.Add("1 s") // want `incompatible units: m and s`
+	q.Sub("1 cm")
+	q.Lt("1 kg") // want `incompatible units: m and kg`
+
+	t, _ := goqty.New(20, "tempC")
+	t.Add("5 tempF") // want `cannot add two temperatures`
+	t.To("tempF")
+
+	r, _ := goqty.Parse("5 m")
+	r, _ = r.Add("1 m")
+	r.To("s")
+
+	var v, _ = goqty.Parse("5 m")
+	v.To("s") // want `incompatible units: m and s`
+
+	var w, err = goqty.New(5, "kg")
+	if err == nil {
+		w.Gt("1 m") // want `incompatible units: kg and m`
+	}
+
+	goqty.SwiftConverter("m/h", "kph")
+	goqty.SwiftConverter("m/h", "kg") // want `incompatible units: m/h and kg`
+}
+
+func typed() {
+	goqty.ParseQuantity[goqty.Length]("5 m")
+	goqty.ParseQuantity[goqty.Length]("5 s") // want `expected length, got time`
+	goqty.NewQuantity[goqty.Speed](5, "m/s")
+	goqty.NewQuantity[goqty.Speed](5, "m") // want `expected speed, got length`
+
+	l, _ := goqty.ParseQuantity[goqty.Length]("5 m")
+	l.To("ft")
+	l.To("kg")   // want `expected length, got mass`
+	(l.To)("kg") // want `expected length, got mass`
+}
@@ -0,0 +1,7 @@
+module example.com/unitcheck
+
+go 1.24.0
+
+require github.com/wjanssens/goqty v0.0.0
+
+replace github.com/wjanssens/goqty => ../..
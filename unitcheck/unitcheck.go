@@ -0,0 +1,359 @@
+// Package unitcheck defines an Analyzer that checks the constant quantities and units passed to goqty.
+//
+// Calls such as goqty.Parse("5 kgs") or q.To("ft/s") are run through the real goqty parser when the program is built,
+// so that unknown units are reported before the code runs.
+// When both the receiver and the argument of a conversion or comparison are constant,
+// e.g. q, _ := goqty.Parse("5 m") followed by q.To("s"), the operation itself is checked too.
+//
+// The analyzer can be run with go vet:
+//
+//	cd unitcheck && go install ./cmd/unitcheck
+//	go vet -vettool=$(which unitcheck) ./...
+package unitcheck
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"reflect"
+
+	"github.com/wjanssens/goqty"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+const goqtyPath = "github.com/wjanssens/goqty"
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "unitcheck",
+	Doc:      "check constant quantities and units passed to goqty",
+	URL:      "https://pkg.go.dev/github.com/wjanssens/goqty/unitcheck",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// how a constant string argument is parsed
+type parser func(string) (*goqty.Qty, error)
+
+// Parse skips text that isn't a unit, e.g. kgs is s, which in a constant is a typo
+var strict = goqty.ParseOptions{DisallowUnrecognizedText: true}
+
+func parseQuantity(s string) (*goqty.Qty, error) {
+	if q, err := goqty.ParseWithOptions(s, strict); err != nil {
+		return nil, fmt.Errorf("invalid quantity %q: %v", s, err)
+	} else {
+		return q, nil
+	}
+}
+
+func parseUnit(s string) (*goqty.Qty, error) {
+	if u, err := goqty.ParseUnit(s); err != nil {
+		return nil, fmt.Errorf("invalid unit %q: %v", s, err)
+	} else if _, err := goqty.ParseWithOptions(s, strict); err != nil {
+		return nil, fmt.Errorf("invalid unit %q: %v", s, err)
+	} else {
+		return goqty.New(1, u)
+	}
+}
+
+// the constant string arguments of the package functions, by position
+var functions = map[string]map[int]parser{
+	"Parse":          {0: parseQuantity},
+	"ParseUnit":      {0: parseUnit},
+	"New":            {1: parseUnit},
+	"ParseQuantity":  {0: parseQuantity},
+	"NewQuantity":    {1: parseUnit},
+	"SwiftConverter": {0: parseUnit, 1: parseUnit},
+}
+
+// the methods of Qty that take a quantity as a string, and the operation to check when the receiver is constant too
+var methods = map[string]func(q *goqty.Qty, arg string) error{
+	"To":        func(q *goqty.Qty, arg string) error { _, err := q.To(arg); return err },
+	"ToPrec":    func(q *goqty.Qty, arg string) error { _, err := q.ToPrec(arg); return err },
+	"Add":       func(q *goqty.Qty, arg string) error { _, err := q.Add(arg); return err },
+	"Sub":       func(q *goqty.Qty, arg string) error { _, err := q.Sub(arg); return err },
+	"Mul":       func(q *goqty.Qty, arg string) error { _, err := q.Mul(arg); return err },
+	"Div":       func(q *goqty.Qty, arg string) error { _, err := q.Div(arg); return err },
+	"CompareTo": func(q *goqty.Qty, arg string) error { _, err := q.CompareTo(arg); return err },
+	"Eq":        func(q *goqty.Qty, arg string) error { _, err := q.Eq(arg); return err },
+	"Lt":        func(q *goqty.Qty, arg string) error { _, err := q.Lt(arg); return err },
+	"Lte":       func(q *goqty.Qty, arg string) error { _, err := q.Lte(arg); return err },
+	"Gt":        func(q *goqty.Qty, arg string) error { _, err := q.Gt(arg); return err },
+	"Gte":       func(q *goqty.Qty, arg string) error { _, err := q.Gte(arg); return err },
+	"Same":      func(q *goqty.Qty, arg string) error { _, err := q.Same(arg); return err },
+	"Atan2":     func(q *goqty.Qty, arg string) error { _, err := q.Atan2(arg); return err },
+}
+
+// the kind names of the type parameters of Quantity, e.g. Length => length
+var kinds = map[string]string{}
+
+func init() {
+	for _, k := range []goqty.QuantityKind{
+		goqty.Unitless{}, goqty.Length{}, goqty.Area{}, goqty.Volume{}, goqty.Mass{}, goqty.Time{},
+		goqty.Speed{}, goqty.Acceleration{}, goqty.Force{}, goqty.Energy{}, goqty.Power{}, goqty.Pressure{},
+		goqty.Density{}, goqty.Frequency{}, goqty.Current{}, goqty.Charge{}, goqty.Potential{}, goqty.Resistance{},
+		goqty.Temperature{}, goqty.Angle{}, goqty.AngularVelocity{}, goqty.VolumetricFlow{},
+		goqty.Information{}, goqty.InformationRate{},
+	} {
+		kinds[reflect.TypeOf(k).Name()] = k.KindName()
+	}
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	constants := constantVariables(pass, inspect)
+
+	inspect.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != goqtyPath {
+			return
+		}
+		if recv := fn.Type().(*types.Signature).Recv(); recv != nil {
+			checkMethod(pass, call, fn, recv, constants)
+		} else {
+			checkFunction(pass, call, fn)
+		}
+	})
+	return nil, nil
+}
+
+func checkFunction(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func) {
+	args := map[int]*goqty.Qty{}
+	for i, parse := range functions[fn.Name()] {
+		if i >= len(call.Args) {
+			continue
+		}
+		if s, ok := constantString(pass, call.Args[i]); ok {
+			if q, err := parse(s); err != nil {
+				pass.Reportf(call.Args[i].Pos(), "%v", err)
+			} else {
+				args[i] = q
+			}
+		}
+	}
+
+	switch fn.Name() {
+	case "SwiftConverter":
+		if src, ok := args[0]; ok {
+			if dst, ok := args[1]; ok {
+				if _, err := src.To(dst); err != nil {
+					pass.Reportf(call.Pos(), "%v", err)
+				}
+			}
+		}
+	case "ParseQuantity", "NewQuantity":
+		for _, q := range args {
+			if kind, ok := typeArgumentKind(pass, call.Fun); ok && q.Kind() != kind {
+				pass.Reportf(call.Pos(), "expected %v, got %v", kind, q.Kind())
+			}
+		}
+	}
+}
+
+func checkMethod(pass *analysis.Pass, call *ast.CallExpr, fn *types.Func, recv *types.Var, constants map[types.Object]*goqty.Qty) {
+	if len(call.Args) != 1 {
+		return
+	}
+	arg, ok := constantString(pass, call.Args[0])
+	if !ok {
+		return
+	}
+
+	switch receiverName(recv.Type()) {
+	case "Qty":
+		op, ok := methods[fn.Name()]
+		if !ok {
+			return
+		}
+		if _, err := parseQuantity(arg); err != nil {
+			pass.Reportf(call.Args[0].Pos(), "%v", err)
+			return
+		}
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		if q, ok := constants[referencedVariable(pass, sel.X)]; ok {
+			if err := op(q, arg); err != nil {
+				pass.Reportf(call.Pos(), "%v", err)
+			}
+		}
+	case "Quantity":
+		if fn.Name() != "To" {
+			return
+		}
+		q, err := parseUnit(arg)
+		if err != nil {
+			pass.Reportf(call.Args[0].Pos(), "%v", err)
+			return
+		}
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		if named, ok := types.Unalias(deref(pass.TypesInfo.TypeOf(sel.X))).(*types.Named); ok && named.TypeArgs().Len() == 1 {
+			if kind, ok := kindOf(named.TypeArgs().At(0)); ok && q.Kind() != kind {
+				pass.Reportf(call.Args[0].Pos(), "expected %v, got %v", kind, q.Kind())
+			}
+		}
+	}
+}
+
+// returns the variables that are assigned exactly once from a call to goqty.Parse or goqty.New with constant arguments,
+// e.g. q, _ := goqty.Parse("5 m") or var q, _ = goqty.Parse("5 m")
+func constantVariables(pass *analysis.Pass, inspect *inspector.Inspector) map[types.Object]*goqty.Qty {
+	result := map[types.Object]*goqty.Qty{}
+	assignments := map[types.Object]int{}
+
+	nodes := []ast.Node{(*ast.AssignStmt)(nil), (*ast.ValueSpec)(nil), (*ast.UnaryExpr)(nil)}
+	inspect.Preorder(nodes, func(n ast.Node) {
+		switch n := n.(type) {
+		case *ast.UnaryExpr:
+			// the variable may be modified through its address
+			if obj := referencedVariable(pass, n.X); obj != nil && n.Op.String() == "&" {
+				assignments[obj]++
+			}
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				if obj := referencedVariable(pass, lhs); obj != nil {
+					assignments[obj]++
+				}
+			}
+			if len(n.Rhs) == 1 && len(n.Lhs) > 0 {
+				if obj := referencedVariable(pass, n.Lhs[0]); obj != nil {
+					if q, ok := constantQuantity(pass, n.Rhs[0]); ok {
+						result[obj] = q
+					}
+				}
+			}
+		case *ast.ValueSpec:
+			for _, name := range n.Names {
+				if obj := pass.TypesInfo.ObjectOf(name); obj != nil {
+					assignments[obj]++
+				}
+			}
+			if len(n.Values) == 1 && len(n.Names) > 0 {
+				if obj, ok := pass.TypesInfo.ObjectOf(n.Names[0]).(*types.Var); ok {
+					if q, ok := constantQuantity(pass, n.Values[0]); ok {
+						result[obj] = q
+					}
+				}
+			}
+		}
+	})
+
+	for obj := range result {
+		if assignments[obj] != 1 {
+			delete(result, obj)
+		}
+	}
+	return result
+}
+
+// returns the quantity of a call to goqty.Parse or goqty.New with constant arguments
+func constantQuantity(pass *analysis.Pass, expr ast.Expr) (*goqty.Qty, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil, false
+	}
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != goqtyPath {
+		return nil, false
+	}
+	var q *goqty.Qty
+	var err error
+	switch fn.Name() {
+	case "Parse":
+		if s, ok := constantString(pass, call.Args[0]); ok {
+			q, err = goqty.Parse(s)
+		}
+	case "New":
+		scalar, ok1 := constantFloat(pass, call.Args[0])
+		units, ok2 := constantString(pass, call.Args[1])
+		if ok1 && ok2 {
+			q, err = goqty.New(scalar, units)
+		}
+	}
+	return q, q != nil && err == nil
+}
+
+// returns the variable an expression refers to, e.g. q in q or *q
+func referencedVariable(pass *analysis.Pass, expr ast.Expr) types.Object {
+	expr = ast.Unparen(expr)
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = ast.Unparen(star.X)
+	}
+	if id, ok := expr.(*ast.Ident); ok {
+		if v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var); ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func constantString(pass *analysis.Pass, expr ast.Expr) (string, bool) {
+	if tv, ok := pass.TypesInfo.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return constant.StringVal(tv.Value), true
+	}
+	return "", false
+}
+
+func constantFloat(pass *analysis.Pass, expr ast.Expr) (float64, bool) {
+	if tv, ok := pass.TypesInfo.Types[expr]; ok && tv.Value != nil {
+		if f, ok := constant.Float64Val(constant.ToFloat(tv.Value)); ok {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// returns the kind of the type argument of a call to a generic function, e.g. length for ParseQuantity[Length]
+func typeArgumentKind(pass *analysis.Pass, fun ast.Expr) (string, bool) {
+	var id *ast.Ident
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		id = f
+	case *ast.SelectorExpr:
+		id = f.Sel
+	default:
+		return "", false
+	}
+	if inst, ok := pass.TypesInfo.Instances[id]; ok && inst.TypeArgs.Len() == 1 {
+		return kindOf(inst.TypeArgs.At(0))
+	}
+	return "", false
+}
+
+// returns the kind name of one of the goqty kind types, e.g. length for goqty.Length
+func kindOf(t types.Type) (string, bool) {
+	if named, ok := types.Unalias(t).(*types.Named); ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == goqtyPath {
+		kind, ok := kinds[named.Obj().Name()]
+		return kind, ok
+	}
+	return "", false
+}
+
+func receiverName(t types.Type) string {
+	if named, ok := types.Unalias(deref(t)).(*types.Named); ok {
+		return named.Obj().Name()
+	}
+	return ""
+}
+
+func deref(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+	return t
+}
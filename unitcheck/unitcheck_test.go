@@ -0,0 +1,12 @@
+package unitcheck_test
+
+import (
+	"testing"
+
+	"github.com/wjanssens/goqty/unitcheck"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), unitcheck.Analyzer, "./a")
+}
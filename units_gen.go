@@ -0,0 +1,503 @@
+// Code generated by go run gen_units.go; DO NOT EDIT.
+
+package goqty
+
+var (
+	// AMU is the mass unit <AMU>, also u, AMU, amu
+	AMU = mustUnit("<AMU>")
+	// AU is the length unit <AU>, also AU, astronomical-unit
+	AU = mustUnit("<AU>")
+	// Acre is the area unit <acre>, also acre, acres
+	Acre = mustUnit("<acre>")
+	// Ampere is the current unit <ampere>, also A, Ampere, ampere, amp, amps
+	Ampere = mustUnit("<ampere>")
+	// AmpereHour is the charge unit <Ah>, also Ah
+	AmpereHour = mustUnit("<Ah>")
+	// Angstrom is the length unit <angstrom>, also ang, angstrom, angstroms
+	Angstrom = mustUnit("<angstrom>")
+	// Arcminute is the angle unit <arcminute>, also arcmin, arcminute, arcminutes
+	Arcminute = mustUnit("<arcminute>")
+	// Arcsecond is the angle unit <arcsecond>, also arcsec, arcsecond, arcseconds
+	Arcsecond = mustUnit("<arcsecond>")
+	// Atm is the pressure unit <atm>, also atm, ATM, atmosphere, atmospheres
+	Atm = mustUnit("<atm>")
+	// Bar is the pressure unit <bar>, also bar, bars
+	Bar = mustUnit("<bar>")
+	// Bara is the pressure unit <bara>, also bara
+	Bara = mustUnit("<bara>")
+	// Barg is the pressure unit <barg>, also barg
+	Barg = mustUnit("<barg>")
+	// BasePair is the counting unit <base-pair>, also bp, base-pair
+	BasePair = mustUnit("<base-pair>")
+	// Becquerel is the radiation unit <becquerel>, also Bq, becquerel, becquerels
+	Becquerel = mustUnit("<becquerel>")
+	// Beerbarrel is the volume unit <beerbarrel>, also bl, bl-us, beerbarrel, beerbarrels, beer-barrel, beer-barrels
+	Beerbarrel = mustUnit("<beerbarrel>")
+	// BeerbarrelImp is the volume unit <beerbarrel-imp>, also blimp, bl-imp, beerbarrel-imp, beerbarrels-imp, beer-barrel-imp, beer-barrels-imp
+	BeerbarrelImp = mustUnit("<beerbarrel-imp>")
+	// Bel is the logarithmic unit <bel>, also bel, bels
+	Bel = mustUnit("<bel>")
+	// Bit is the information unit <bit>, also b, bit, bits
+	Bit = mustUnit("<bit>")
+	// BitsPerSecond is the information_rate unit <bps>, also bps
+	BitsPerSecond = mustUnit("<bps>")
+	// Bpm is the rate unit <bpm>, also bpm
+	Bpm = mustUnit("<bpm>")
+	// Btu is the energy unit <btu>, also BTU, btu, BTUs
+	Btu = mustUnit("<btu>")
+	// Bushel is the volume unit <bushel>, also bu, bsh, bushel, bushels
+	Bushel = mustUnit("<bushel>")
+	// Byte is the information unit <byte>, also B, byte, bytes
+	Byte = mustUnit("<byte>")
+	// BytesPerSecond is the information_rate unit <Bps>, also Bps
+	BytesPerSecond = mustUnit("<Bps>")
+	// Calorie is the energy unit <calorie>, also cal, calorie, calories
+	Calorie = mustUnit("<calorie>")
+	// Candela is the luminosity unit <candela>, also cd, candela
+	Candela = mustUnit("<candela>")
+	// Carat is the mass unit <carat>, also ct, carat, carats
+	Carat = mustUnit("<carat>")
+	// Cell is the counting unit <cell>, also cells, cell
+	Cell = mustUnit("<cell>")
+	// Celsius is the temperature unit <celsius>, also °C, degC, celsius, celsius, centigrade
+	Celsius = mustUnit("<celsius>")
+	// Century is the time unit <century>, also century, centuries
+	Century = mustUnit("<century>")
+	// Cmh2o is the pressure unit <cmh2o>, also cmH2O, cmh2o
+	Cmh2o = mustUnit("<cmh2o>")
+	// Coulomb is the charge unit <coulomb>, also C, coulomb, Coulomb
+	Coulomb = mustUnit("<coulomb>")
+	// Count is the counting unit <count>, also count
+	Count = mustUnit("<count>")
+	// Cpm is the rate unit <cpm>, also cpm
+	Cpm = mustUnit("<cpm>")
+	// Cup is the volume unit <cup>, also cu, cup, cups
+	Cup = mustUnit("<cup>")
+	// Curie is the radiation unit <curie>, also Ci, curie, curies
+	Curie = mustUnit("<curie>")
+	// Dalton is the mass unit <dalton>, also Da, Dalton, Daltons, dalton, daltons
+	Dalton = mustUnit("<dalton>")
+	// Datamile is the length unit <datamile>, also DM, datamile
+	Datamile = mustUnit("<datamile>")
+	// Day is the time unit <day>, also d, day, days
+	Day = mustUnit("<day>")
+	// Decade is the time unit <decade>, also decade, decades
+	Decade = mustUnit("<decade>")
+	// Decibel is the logarithmic unit <decibel>, also dB, decibel, decibels
+	Decibel = mustUnit("<decibel>")
+	// DecibelMicrovolt is the logarithmic unit <decibel-microvolt>, also dBµV, dBμV, dBuV
+	DecibelMicrovolt = mustUnit("<decibel-microvolt>")
+	// DecibelMilliwatt is the logarithmic unit <decibel-milliwatt>, also dBm, dBmW
+	DecibelMilliwatt = mustUnit("<decibel-milliwatt>")
+	// DecibelSpl is the logarithmic unit <decibel-spl>, also dBSPL, dB-SPL
+	DecibelSpl = mustUnit("<decibel-spl>")
+	// DecibelVolt is the logarithmic unit <decibel-volt>, also dBV
+	DecibelVolt = mustUnit("<decibel-volt>")
+	// DecibelWatt is the logarithmic unit <decibel-watt>, also dBW
+	DecibelWatt = mustUnit("<decibel-watt>")
+	// Degree is the angle unit <degree>, also °, deg, degree, degrees
+	Degree = mustUnit("<degree>")
+	// Dot is the resolution unit <dot>, also dot, dots
+	Dot = mustUnit("<dot>")
+	// Dozen is the prefix_only unit <dozen>, also doz, dz, dozen
+	Dozen = mustUnit("<dozen>")
+	// Dpi is the typography unit <dpi>, also dpi
+	Dpi = mustUnit("<dpi>")
+	// Dpm is the rate unit <dpm>, also dpm
+	Dpm = mustUnit("<dpm>")
+	// Dram is the mass unit <dram>, also dram, drams, dr
+	Dram = mustUnit("<dram>")
+	// Dyne is the force unit <dyne>, also dyn, dyne
+	Dyne = mustUnit("<dyne>")
+	// Each is the counting unit <each>, also each
+	Each = mustUnit("<each>")
+	// Electronvolt is the energy unit <electronvolt>, also eV, electronvolt, electronvolts
+	Electronvolt = mustUnit("<electronvolt>")
+	// ElementaryCharge is the charge unit <elementary-charge>, also e
+	ElementaryCharge = mustUnit("<elementary-charge>")
+	// EnzymeUnit is the activity unit <unit>, also U, enzUnit, unit
+	EnzymeUnit = mustUnit("<unit>")
+	// Erg is the energy unit <erg>, also erg, ergs
+	Erg = mustUnit("<erg>")
+	// Fahrenheit is the temperature unit <fahrenheit>, also °F, degF, fahrenheit
+	Fahrenheit = mustUnit("<fahrenheit>")
+	// Farad is the capacitance unit <farad>, also F, farad, Farad
+	Farad = mustUnit("<farad>")
+	// Fathom is the length unit <fathom>, also fathom, fathoms
+	Fathom = mustUnit("<fathom>")
+	// FluidOunce is the volume unit <fluid-ounce>, also floz, fluid-ounce, fluid-ounces
+	FluidOunce = mustUnit("<fluid-ounce>")
+	// FluidOunceImp is the volume unit <fluid-ounce-imp>, also flozimp, floz-imp, fluid-ounce-imp, fluid-ounces-imp
+	FluidOunceImp = mustUnit("<fluid-ounce-imp>")
+	// FoodCalorie is the energy unit <Calorie>, also Cal, Calorie, Calories
+	FoodCalorie = mustUnit("<Calorie>")
+	// Foot is the length unit <foot>, also ft, foot, feet, '
+	Foot = mustUnit("<foot>")
+	// Fortnight is the time unit <fortnight>, also fortnight, fortnights
+	Fortnight = mustUnit("<fortnight>")
+	// Fps is the speed unit <fps>, also fps
+	Fps = mustUnit("<fps>")
+	// Furlong is the length unit <furlong>, also furlong, furlongs
+	Furlong = mustUnit("<furlong>")
+	// Gal is the acceleration unit <Gal>, also Gal
+	Gal = mustUnit("<Gal>")
+	// Gallon is the volume unit <gallon>, also gal, gallon, gallons
+	Gallon = mustUnit("<gallon>")
+	// GallonImp is the volume unit <gallon-imp>, also galimp, gallon-imp, gallons-imp
+	GallonImp = mustUnit("<gallon-imp>")
+	// Gauss is the magnetism unit <gauss>, also G, gauss
+	Gauss = mustUnit("<gauss>")
+	// Gee is the acceleration unit <gee>, also gee
+	Gee = mustUnit("<gee>")
+	// Gradian is the angle unit <gradian>, also gon, grad, gradian, grads
+	Gradian = mustUnit("<gradian>")
+	// Grain is the mass unit <grain>, also grain, grains, gr
+	Grain = mustUnit("<grain>")
+	// Gram is the mass unit <gram>, also g, gram, grams, gramme, grammes
+	Gram = mustUnit("<gram>")
+	// GramForce is the force unit <gram-force>, also gf, gram-force
+	GramForce = mustUnit("<gram-force>")
+	// Gray is the radiation unit <gray>, also Gy, gray, grays
+	Gray = mustUnit("<gray>")
+	// Gross is the prefix_only unit <gross>, also gr, gross
+	Gross = mustUnit("<gross>")
+	// Hectare is the area unit <hectare>, also hectare
+	Hectare = mustUnit("<hectare>")
+	// Henry is the inductance unit <henry>, also H, Henry, henry
+	Henry = mustUnit("<henry>")
+	// Hertz is the frequency unit <hertz>, also Hz, hertz, Hertz
+	Hertz = mustUnit("<hertz>")
+	// Horsepower is the power unit <horsepower>, also hp, horsepower
+	Horsepower = mustUnit("<horsepower>")
+	// Hour is the time unit <hour>, also h, hr, hrs, hour, hours
+	Hour = mustUnit("<hour>")
+	// InHg is the pressure unit <inHg>, also inHg
+	InHg = mustUnit("<inHg>")
+	// Inch is the length unit <inch>, also in, inch, inches, "
+	Inch = mustUnit("<inch>")
+	// Inh2o is the pressure unit <inh2o>, also inH2O, inh2o
+	Inh2o = mustUnit("<inh2o>")
+	// Joule is the energy unit <joule>, also J, joule, Joule, joules, Joules
+	Joule = mustUnit("<joule>")
+	// Katal is the activity unit <katal>, also kat, katal, Katal
+	Katal = mustUnit("<katal>")
+	// Kelvin is the temperature unit <kelvin>, also °K, degK, kelvin
+	Kelvin = mustUnit("<kelvin>")
+	// Kilogram is the mass unit <kilogram>, also kg, kilogram, kilograms
+	Kilogram = mustUnit("<kilogram>")
+	// KilogramForce is the force unit <kilogram-force>, also kgf, kilogram-force, kilopond, kp
+	KilogramForce = mustUnit("<kilogram-force>")
+	// Knot is the speed unit <knot>, also kt, kn, kts, knot, knots
+	Knot = mustUnit("<knot>")
+	// Kph is the speed unit <kph>, also kph
+	Kph = mustUnit("<kph>")
+	// League is the length unit <league>, also league, leagues
+	League = mustUnit("<league>")
+	// LightMinute is the length unit <light-minute>, also lmin, light-minute
+	LightMinute = mustUnit("<light-minute>")
+	// LightSecond is the length unit <light-second>, also ls, light-second
+	LightSecond = mustUnit("<light-second>")
+	// LightYear is the length unit <light-year>, also ly, light-year
+	LightYear = mustUnit("<light-year>")
+	// Liter is the volume unit <liter>, also l, L, liter, liters, litre, litres
+	Liter = mustUnit("<liter>")
+	// Lumen is the luminous_power unit <lumen>, also lm, lumen
+	Lumen = mustUnit("<lumen>")
+	// Lux is the illuminance unit <lux>, also lux
+	Lux = mustUnit("<lux>")
+	// Maxwell is the magnetism unit <maxwell>, also Mx, maxwell, maxwells
+	Maxwell = mustUnit("<maxwell>")
+	// Meter is the length unit <meter>, also m, meter, meters, metre, metres
+	Meter = mustUnit("<meter>")
+	// MetricTon is the mass unit <metric-ton>, also t, tonne, metric-ton
+	MetricTon = mustUnit("<metric-ton>")
+	// Mil is the length unit <mil>, also mil, mils
+	Mil = mustUnit("<mil>")
+	// Mile is the length unit <mile>, also mi, mile, miles
+	Mile = mustUnit("<mile>")
+	// Minute is the time unit <minute>, also min, mins, minute, minutes
+	Minute = mustUnit("<minute>")
+	// MmHg is the pressure unit <mmHg>, also mmHg
+	MmHg = mustUnit("<mmHg>")
+	// Molar is the molar_concentration unit <molar>, also M, molar
+	Molar = mustUnit("<molar>")
+	// Mole is the substance unit <mole>, also mol, mole
+	Mole = mustUnit("<mole>")
+	// Molecule is the counting unit <molecule>, also molecule, molecules
+	Molecule = mustUnit("<molecule>")
+	// Mph is the speed unit <mph>, also mph
+	Mph = mustUnit("<mph>")
+	// NautMile is the length unit <naut-mile>, also nmi, naut-mile
+	NautMile = mustUnit("<naut-mile>")
+	// Neper is the logarithmic unit <neper>, also Np, neper, nepers
+	Neper = mustUnit("<neper>")
+	// Newton is the force unit <newton>, also N, Newton, newton
+	Newton = mustUnit("<newton>")
+	// Nucleotide is the counting unit <nucleotide>, also nt, nucleotide
+	Nucleotide = mustUnit("<nucleotide>")
+	// Oersted is the magnetism unit <oersted>, also Oe, oersted, oersteds
+	Oersted = mustUnit("<oersted>")
+	// Ohm is the resistance unit <ohm>, also Ω, Ω, Ohm, ohm
+	Ohm = mustUnit("<ohm>")
+	// Oilbarrel is the volume unit <oilbarrel>, also bbl, oilbarrel, oilbarrels, oil-barrel, oil-barrels
+	Oilbarrel = mustUnit("<oilbarrel>")
+	// Ounce is the mass unit <ounce>, also oz, ounce, ounces
+	Ounce = mustUnit("<ounce>")
+	// Parsec is the length unit <parsec>, also pc, parsec, parsecs
+	Parsec = mustUnit("<parsec>")
+	// Pascal is the pressure unit <pascal>, also Pa, pascal, Pascal
+	Pascal = mustUnit("<pascal>")
+	// PascalAbs is the pressure unit <pascal-abs>, also Pa-abs, pascal-abs
+	PascalAbs = mustUnit("<pascal-abs>")
+	// Percent is the prefix_only unit <percent>, also %, percent
+	Percent = mustUnit("<percent>")
+	// Pica is the length unit <pica>, also pc, pica, picas
+	Pica = mustUnit("<pica>")
+	// Pint is the volume unit <pint>, also pt, pint, pints
+	Pint = mustUnit("<pint>")
+	// PintImp is the volume unit <pint-imp>, also ptimp, pint-imp, pints-imp
+	PintImp = mustUnit("<pint-imp>")
+	// Pixel is the resolution unit <pixel>, also pixel, px
+	Pixel = mustUnit("<pixel>")
+	// Point is the length unit <point>, also pt, point, points
+	Point = mustUnit("<point>")
+	// Poise is the viscosity unit <poise>, also P, poise
+	Poise = mustUnit("<poise>")
+	// Pound is the mass unit <pound>, also lbs, lb, pound, pounds, #
+	Pound = mustUnit("<pound>")
+	// PoundForce is the force unit <pound-force>, also lbf, pound-force
+	PoundForce = mustUnit("<pound-force>")
+	// Ppb is the prefix_only unit <ppb>, also ppb
+	Ppb = mustUnit("<ppb>")
+	// Ppi is the resolution unit <ppi>, also ppi
+	Ppi = mustUnit("<ppi>")
+	// Ppm is the prefix_only unit <ppm>, also ppm
+	Ppm = mustUnit("<ppm>")
+	// Ppq is the prefix_only unit <ppq>, also ppq
+	Ppq = mustUnit("<ppq>")
+	// Ppt is the prefix_only unit <ppt>, also ppt
+	Ppt = mustUnit("<ppt>")
+	// Psi is the pressure unit <psi>, also psi
+	Psi = mustUnit("<psi>")
+	// Psia is the pressure unit <psia>, also psia
+	Psia = mustUnit("<psia>")
+	// Psig is the pressure unit <psig>, also psig
+	Psig = mustUnit("<psig>")
+	// Quart is the volume unit <quart>, also qt, quart, quarts
+	Quart = mustUnit("<quart>")
+	// Radian is the angle unit <radian>, also rad, radian, radians
+	Radian = mustUnit("<radian>")
+	// Rankine is the temperature unit <rankine>, also °R, degR, rankine
+	Rankine = mustUnit("<rankine>")
+	// Redshift is the length unit <redshift>, also z, red-shift, redshift
+	Redshift = mustUnit("<redshift>")
+	// Rod is the length unit <rod>, also rd, rod, rods
+	Rod = mustUnit("<rod>")
+	// Roentgen is the radiation unit <roentgen>, also R, roentgen
+	Roentgen = mustUnit("<roentgen>")
+	// Rotation is the angle unit <rotation>, also rotation
+	Rotation = mustUnit("<rotation>")
+	// Rpm is the angular_velocity unit <rpm>, also rpm
+	Rpm = mustUnit("<rpm>")
+	// Second is the time unit <second>, also s, sec, secs, second, seconds
+	Second = mustUnit("<second>")
+	// ShortTon is the mass unit <short-ton>, also tn, ton, short-ton
+	ShortTon = mustUnit("<short-ton>")
+	// Siemens is the conductance unit <siemens>, also S, Siemens, siemens
+	Siemens = mustUnit("<siemens>")
+	// Sievert is the radiation unit <sievert>, also Sv, sievert, sieverts
+	Sievert = mustUnit("<sievert>")
+	// Slug is the mass unit <slug>, also slug, slugs
+	Slug = mustUnit("<slug>")
+	// Sqft is the area unit <sqft>, also sqft
+	Sqft = mustUnit("<sqft>")
+	// Steradian is the solid_angle unit <steradian>, also sr, steradian, steradians
+	Steradian = mustUnit("<steradian>")
+	// Stokes is the viscosity unit <stokes>, also St, stokes
+	Stokes = mustUnit("<stokes>")
+	// Stone is the mass unit <stone>, also stone, stones, st
+	Stone = mustUnit("<stone>")
+	// Tablespoon is the volume unit <tablespoon>, also tb, tbsp, tbs, tablespoon, tablespoons
+	Tablespoon = mustUnit("<tablespoon>")
+	// Teaspoon is the volume unit <teaspoon>, also tsp, teaspoon, teaspoons
+	Teaspoon = mustUnit("<teaspoon>")
+	// TempC is the temperature unit <temp-C>, also tempC, temp-C
+	TempC = mustUnit("<temp-C>")
+	// TempF is the temperature unit <temp-F>, also tempF, temp-F
+	TempF = mustUnit("<temp-F>")
+	// TempK is the temperature unit <temp-K>, also tempK, temp-K
+	TempK = mustUnit("<temp-K>")
+	// TempR is the temperature unit <temp-R>, also tempR, temp-R
+	TempR = mustUnit("<temp-R>")
+	// Tesla is the magnetism unit <tesla>, also T, tesla, teslas
+	Tesla = mustUnit("<tesla>")
+	// ThermUS is the energy unit <therm-US>, also th, therm, therms, Therm, therm-US
+	ThermUS = mustUnit("<therm-US>")
+	// Torr is the pressure unit <torr>, also torr
+	Torr = mustUnit("<torr>")
+	// Volt is the potential unit <volt>, also V, Volt, volt, volts
+	Volt = mustUnit("<volt>")
+	// VoltAmpere is the power unit <volt-ampere>, also VA, volt-ampere
+	VoltAmpere = mustUnit("<volt-ampere>")
+	// VoltAmpereReactive is the power unit <volt-ampere-reactive>, also var, Var, VAr, VAR, volt-ampere-reactive
+	VoltAmpereReactive = mustUnit("<volt-ampere-reactive>")
+	// Watt is the power unit <watt>, also W, watt, watts
+	Watt = mustUnit("<watt>")
+	// WattHour is the energy unit <Wh>, also Wh
+	WattHour = mustUnit("<Wh>")
+	// Weber is the magnetism unit <weber>, also Wb, weber, webers
+	Weber = mustUnit("<weber>")
+	// Week is the time unit <week>, also wk, week, weeks
+	Week = mustUnit("<week>")
+	// Wtpercent is the molar_concentration unit <wtpercent>, also wt%, wtpercent
+	Wtpercent = mustUnit("<wtpercent>")
+	// Yard is the length unit <yard>, also yd, yard, yards
+	Yard = mustUnit("<yard>")
+	// Year is the time unit <year>, also y, yr, year, years, annum
+	Year = mustUnit("<year>")
+)
+
+// Atto applies the prefix <atto>, also a, Atto, atto, to a unit
+func Atto(u Unit) Unit {
+	return mustPrefix("<atto>", u)
+}
+
+// Centi applies the prefix <centi>, also c, Centi, centi, to a unit
+func Centi(u Unit) Unit {
+	return mustPrefix("<centi>", u)
+}
+
+// Deca applies the prefix <deca>, also da, Deca, deca, Deka, deka, to a unit
+func Deca(u Unit) Unit {
+	return mustPrefix("<deca>", u)
+}
+
+// Deci applies the prefix <deci>, also d, Deci, deci, to a unit
+func Deci(u Unit) Unit {
+	return mustPrefix("<deci>", u)
+}
+
+// Eibi applies the prefix <eibi>, also Ei, Eibi, eibi, to a unit
+func Eibi(u Unit) Unit {
+	return mustPrefix("<eibi>", u)
+}
+
+// Exa applies the prefix <exa>, also E, Exa, exa, to a unit
+func Exa(u Unit) Unit {
+	return mustPrefix("<exa>", u)
+}
+
+// Femto applies the prefix <femto>, also f, Femto, femto, to a unit
+func Femto(u Unit) Unit {
+	return mustPrefix("<femto>", u)
+}
+
+// Gibi applies the prefix <gibi>, also Gi, Gibi, gibi, to a unit
+func Gibi(u Unit) Unit {
+	return mustPrefix("<gibi>", u)
+}
+
+// Giga applies the prefix <giga>, also G, Giga, giga, to a unit
+func Giga(u Unit) Unit {
+	return mustPrefix("<giga>", u)
+}
+
+// Googol applies the prefix <googol>, also googol, to a unit
+func Googol(u Unit) Unit {
+	return mustPrefix("<googol>", u)
+}
+
+// Hecto applies the prefix <hecto>, also h, Hecto, hecto, to a unit
+func Hecto(u Unit) Unit {
+	return mustPrefix("<hecto>", u)
+}
+
+// Kibi applies the prefix <kibi>, also Ki, Kibi, kibi, to a unit
+func Kibi(u Unit) Unit {
+	return mustPrefix("<kibi>", u)
+}
+
+// Kilo applies the prefix <kilo>, also k, Kilo, kilo, to a unit
+func Kilo(u Unit) Unit {
+	return mustPrefix("<kilo>", u)
+}
+
+// Mega applies the prefix <mega>, also M, Mega, mega, to a unit
+func Mega(u Unit) Unit {
+	return mustPrefix("<mega>", u)
+}
+
+// Mibi applies the prefix <mibi>, also Mi, Mibi, mibi, to a unit
+func Mibi(u Unit) Unit {
+	return mustPrefix("<mibi>", u)
+}
+
+// Micro applies the prefix <micro>, also µ, μ, u, Micro, micro, to a unit
+func Micro(u Unit) Unit {
+	return mustPrefix("<micro>", u)
+}
+
+// Milli applies the prefix <milli>, also m, Milli, milli, to a unit
+func Milli(u Unit) Unit {
+	return mustPrefix("<milli>", u)
+}
+
+// Nano applies the prefix <nano>, also n, Nano, nano, to a unit
+func Nano(u Unit) Unit {
+	return mustPrefix("<nano>", u)
+}
+
+// Peta applies the prefix <peta>, also P, Peta, peta, to a unit
+func Peta(u Unit) Unit {
+	return mustPrefix("<peta>", u)
+}
+
+// Pibi applies the prefix <pibi>, also Pi, Pibi, pibi, to a unit
+func Pibi(u Unit) Unit {
+	return mustPrefix("<pibi>", u)
+}
+
+// Pico applies the prefix <pico>, also p, Pico, pico, to a unit
+func Pico(u Unit) Unit {
+	return mustPrefix("<pico>", u)
+}
+
+// Tera applies the prefix <tera>, also T, Tera, tera, to a unit
+func Tera(u Unit) Unit {
+	return mustPrefix("<tera>", u)
+}
+
+// Tibi applies the prefix <tibi>, also Ti, Tibi, tibi, to a unit
+func Tibi(u Unit) Unit {
+	return mustPrefix("<tibi>", u)
+}
+
+// Yibi applies the prefix <yibi>, also Yi, Yibi, yibi, to a unit
+func Yibi(u Unit) Unit {
+	return mustPrefix("<yibi>", u)
+}
+
+// Yocto applies the prefix <yocto>, also y, Yocto, yocto, to a unit
+func Yocto(u Unit) Unit {
+	return mustPrefix("<yocto>", u)
+}
+
+// Yotta applies the prefix <yotta>, also Y, Yotta, yotta, to a unit
+func Yotta(u Unit) Unit {
+	return mustPrefix("<yotta>", u)
+}
+
+// Zepto applies the prefix <zepto>, also z, Zepto, zepto, to a unit
+func Zepto(u Unit) Unit {
+	return mustPrefix("<zepto>", u)
+}
+
+// Zetta applies the prefix <zetta>, also Z, Zetta, zetta, to a unit
+func Zetta(u Unit) Unit {
+	return mustPrefix("<zetta>", u)
+}
+
+// Zibi applies the prefix <zibi>, also Zi, Zibi, zibi, to a unit
+func Zibi(u Unit) Unit {
+	return mustPrefix("<zibi>", u)
+}
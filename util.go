@@ -64,6 +64,32 @@ func divSafe(num, den float64) (float64, error) {
 	return mulSafe(num, invDen), nil
 }
 
+// Raises f to a non-negative integer power by repeated squaring, e.g. powInt(10, 3) => 1000
+func powInt(f float64, n int) float64 {
+	result := float64(1)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result *= f
+		}
+		f *= f
+	}
+	return result
+}
+
+// Raises f to a non-negative integer power like powInt, avoiding floating errors like mulSafe
+func powSafe(f float64, n int) float64 {
+	result := float64(1)
+	for ; n > 0; n >>= 1 {
+		if n&1 == 1 {
+			result = mulSafe(result, f)
+		}
+		if n > 1 {
+			f = mulSafe(f, f)
+		}
+	}
+	return result
+}
+
 // Rounds value at the specified number of decimals
 func round(f, decimals float64) float64 {
 	return math.Round(f*math.Pow(10, decimals)) / math.Pow(10, decimals)
@@ -88,3 +114,33 @@ func isFinite(f float64) bool {
 func identity(value float64) (float64, error) {
 	return value, nil
 }
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Returns the n-th root of a non-negative number,
+// using the exact library functions for square and cube roots
+func nthRoot(f float64, n int) float64 {
+	switch n {
+	case 1:
+		return f
+	case 2:
+		return math.Sqrt(f)
+	case 3:
+		return math.Cbrt(f)
+	default:
+		return math.Pow(f, 1/float64(n))
+	}
+}
+
+// Returns the greatest common divisor of two non-negative integers
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}